@@ -6,19 +6,35 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"poc-auth-svc/internal/application/usecases"
+	"poc-auth-svc/internal/domain/repositories"
 	"poc-auth-svc/internal/domain/services"
+	"poc-auth-svc/internal/domain/valueobjects"
 	"poc-auth-svc/internal/infrastructure/database"
 	"poc-auth-svc/internal/infrastructure/http/handlers"
+	"poc-auth-svc/internal/infrastructure/http/middleware"
 	"poc-auth-svc/internal/infrastructure/http/routes"
+	"poc-auth-svc/internal/infrastructure/mail"
+	"poc-auth-svc/internal/infrastructure/notification"
 	"poc-auth-svc/internal/infrastructure/persistence"
 	"poc-auth-svc/internal/infrastructure/security"
+	"poc-auth-svc/internal/infrastructure/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// Cada cuánto se recargan las claves de firma guardadas y se evalúa la rotación programada
+	keyRotationCheckInterval = time.Minute
+	authorizationCodeTTL     = 5 * time.Minute
+	webAuthnChallengeTTL     = 5 * time.Minute
 )
 
 func main() {
@@ -29,9 +45,7 @@ func main() {
 	// Configuración desde variables de entorno
 	mongoUri := getEnv("MONGO_URI", "")
 	dbName := getEnv("DB_NAME", "auth_svc")
-	jwtSecret := getEnv("JWT_SECRET", "12454sd32")
 	port := getEnv("PORT", "3000")
-	fmt.Println(jwtSecret)
 	// Conectar a mongoDB
 	mongoClient, err := database.NewMongoClient(mongoUri)
 	if err != nil {
@@ -39,36 +53,113 @@ func main() {
 	}
 	defer mongoClient.Disconnect(context.Background())
 	db := mongoClient.Database(dbName)
+	if err := persistence.EnsureIndexes(context.Background(), db); err != nil {
+		log.Fatal("Failed to create MongoDB indexes: ", err)
+	}
+	emailRules := loadEmailRules()
+	if err := persistence.MigrateUserEmails(context.Background(), db, emailRules); err != nil {
+		log.Fatal("Failed to migrate user emails: ", err)
+	}
 
 	// Inicializar dependencias (Dependency Injection)
-	hasher := security.NewBcryptHasher()
+	hasher, err := loadPasswordHasher()
+	if err != nil {
+		log.Fatal("Failed to configure password hashing: ", err)
+	}
 	jwtExpirationHours, _ := strconv.ParseInt(getEnv("JWT_EXPIRATION_HOURS", "2"), 10, 64)
+	keyRing, err := loadKeyRing(context.Background(), db, time.Hour*time.Duration(jwtExpirationHours))
+	if err != nil {
+		log.Fatal("Failed to load JWT signing keys: ", err)
+	}
 	jwtWrapper := usecases.JwtWrapper{
-		SecretKey:       jwtSecret,
+		KeyRing:         keyRing,
 		Issuer:          getEnv("JWT_ISSUER", "go"),
 		ExpirationHours: jwtExpirationHours,
 	}
+	refreshExpirationHours, _ := strconv.ParseInt(getEnv("REFRESH_TOKEN_EXPIRATION_HOURS", "720"), 10, 64)
 	userRepo := persistence.NewMongoUserRepository(db)
-	authService := services.NewAuthService(userRepo, hasher)
-	authUseCase := usecases.NewAuthUseCase(authService, jwtWrapper)
+	refreshTokenRepo := persistence.NewMongoRefreshTokenRepository(db)
+	revocationRepo := persistence.NewMongoTokenRevocationRepository(db)
+	clientRepo := persistence.NewMongoClientRepository(db)
+	authorizationCodeRepo := persistence.NewMongoAuthorizationCodeRepository(db)
+	webAuthnCredentialRepo := persistence.NewMongoWebAuthnCredentialRepository(db)
+	webAuthnSessionRepo := persistence.NewMongoWebAuthnSessionRepository(db)
+	passwordResetTokenRepo := persistence.NewMongoPasswordResetTokenRepository(db)
+	authorizationService := services.NewAuthorizationService(persistence.NewMongoRoleRepository(db), persistence.NewMongoPermissionRepository(db))
+	if err := authorizationService.EnsureBuiltins(context.Background()); err != nil {
+		log.Fatal("Failed to create built-in roles: ", err)
+	}
+	jwtWrapper.Permissions = authorizationService
+	authPolicy := services.AuthPolicy{
+		RequireVerifiedEmail: getEnv("EMAIL_VERIFICATION_REQUIRED", "false") == "true",
+		EmailRules:           emailRules,
+	}
+	loginThrottle := services.NewLoginThrottleService(loadLoginAttemptRepository(db), loadLockoutPolicy())
+	breachedChecker, err := loadBreachedPasswordChecker()
+	if err != nil {
+		log.Fatal("Failed to initialize breached password check: ", err)
+	}
+	passwordPolicy := services.NewPasswordPolicyService(loadPasswordPolicy(), hasher, breachedChecker)
+	authService := services.NewAuthService(userRepo, hasher, loginThrottle, passwordPolicy, authPolicy)
+	refreshService := services.NewRefreshTokenService(refreshTokenRepo, time.Hour*time.Duration(refreshExpirationHours))
+	revocationService := services.NewTokenRevocationService(revocationRepo, refreshTokenRepo, time.Hour*time.Duration(jwtExpirationHours))
+	oauthService := services.NewOAuthService(clientRepo, authorizationCodeRepo, hasher, authorizationCodeTTL)
+	mfaCipher, err := loadMFACipher()
+	if err != nil {
+		log.Fatal("Failed to initialize MFA cipher: ", err)
+	}
+	mfaService := services.NewMFAService(userRepo, hasher, security.NewTOTPProvider(getEnv("MFA_ISSUER", "poc-auth-svc")), mfaCipher, loginThrottle)
+	passwordResetTTLMinutes, _ := strconv.ParseInt(getEnv("PASSWORD_RESET_TOKEN_TTL_MINUTES", "30"), 10, 64)
+	notifier := loadNotifier(port)
+	passwordService := services.NewPasswordService(userRepo, passwordResetTokenRepo, hasher, passwordPolicy, notifier, time.Minute*time.Duration(passwordResetTTLMinutes), emailRules)
+	webAuthnRPID := getEnv("WEBAUTHN_RP_ID", "localhost")
+	webAuthnOrigins := strings.Split(getEnv("WEBAUTHN_ORIGINS", "http://localhost:"+port), ",")
+	webAuthnVerifier := security.NewWebAuthnVerifier(webAuthnRPID, webAuthnOrigins, true)
+	webAuthnService := services.NewWebAuthnService(userRepo, webAuthnCredentialRepo, webAuthnSessionRepo, webAuthnVerifier, webAuthnChallengeTTL, emailRules)
+	authUseCase := usecases.NewAuthUseCase(authService, oauthService, refreshService, revocationService, mfaService, authorizationService, notifier, authPolicy, jwtWrapper)
+	oidcUseCase := usecases.NewOIDCUseCase(authService, oauthService, refreshService, mfaService, authUseCase, jwtWrapper, usecases.OIDCConfig{
+		IssuerURL: getEnv("OIDC_ISSUER_URL", "http://localhost:"+port),
+	})
+	keyUseCase := usecases.NewKeyUseCase(keyRing)
+	mfaUseCase := usecases.NewMFAUseCase(mfaService, security.NewQRCodeRenderer())
+	emailVerificationUseCase := usecases.NewEmailVerificationUseCase(services.NewEmailVerificationService(userRepo, emailRules), notifier, jwtWrapper)
+	passwordUseCase := usecases.NewPasswordUseCase(passwordService, revocationService, refreshService, jwtWrapper)
+	webAuthnUseCase := usecases.NewWebAuthnUseCase(webAuthnService, authService, refreshService, jwtWrapper, usecases.WebAuthnConfig{
+		RPID:       webAuthnRPID,
+		RPName:     getEnv("WEBAUTHN_RP_NAME", "poc-auth-svc"),
+		Algorithms: security.SupportedCOSEAlgorithms,
+		Timeout:    webAuthnChallengeTTL,
+	})
 	authHandler := handlers.NewAuthHandler(authUseCase)
+	keyHandler := handlers.NewKeyHandler(keyUseCase)
+	oidcHandler := handlers.NewOIDCHandler(oidcUseCase)
+	mfaHandler := handlers.NewMFAHandler(mfaUseCase, authUseCase)
+	webAuthnHandler := handlers.NewWebAuthnHandler(webAuthnUseCase)
+	passwordHandler := handlers.NewPasswordHandler(passwordUseCase)
+	emailVerificationHandler := handlers.NewEmailVerificationHandler(emailVerificationUseCase)
+	roleHandler := handlers.NewRoleHandler(usecases.NewRoleUseCase(authorizationService, userRepo))
+	userAdminService := services.NewUserAdminService(userRepo, webAuthnCredentialRepo, passwordResetTokenRepo, authorizationService)
+	userAdminHandler := handlers.NewUserAdminHandler(usecases.NewUserAdminUseCase(userAdminService, passwordService, revocationService))
+	authMiddleware := middleware.NewAuthMiddleware(authUseCase)
+
+	// Rotación programada de claves de firma; JWT_KEY_ROTATION_HOURS=0 solo purga las claves retiradas
+	keyRotationHours, _ := strconv.ParseInt(getEnv("JWT_KEY_ROTATION_HOURS", "0"), 10, 64)
+	go keyUseCase.RunScheduledRotation(context.Background(), keyRotationCheckInterval, time.Hour*time.Duration(keyRotationHours))
+
+	// Formato de error por defecto; los clientes pueden pedir el otro con el header Accept
+	if err := utils.ConfigureErrorRendering(utils.ErrorFormat(getEnv("ERROR_FORMAT", string(utils.ErrorFormatStandard))), getEnv("PROBLEM_TYPE_BASE_URI", "")); err != nil {
+		log.Fatal("Failed to configure error rendering: ", err)
+	}
 
 	// Configurar fiber
 	app := fiber.New(fiber.Config{
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			return c.Status(code).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		},
+		ErrorHandler: handlers.ErrorHandler,
 	})
 
 	// Middlewares
 	app.Use(logger.New())
 	app.Use(cors.New())
+	app.Use(middleware.Localization())
 
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -77,7 +168,7 @@ func main() {
 		})
 	})
 
-	routes.SetupRoutes(app, authHandler)
+	routes.SetupRoutes(app, authMiddleware, authHandler, keyHandler, oidcHandler, mfaHandler, webAuthnHandler, passwordHandler, emailVerificationHandler, roleHandler, userAdminHandler)
 	log.Printf("Auth service running on port %s", port)
 	log.Fatal(app.Listen(":" + port))
 }
@@ -88,3 +179,215 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// loadKeyRing carga el key ring de JWT guardado en mongo, compartido por todas las réplicas. La clave
+// según JWT_SIGNING_ALG solo se usa si todavía no hay una activa guardada; las claves previas de
+// JWT_VERIFICATION_KEY_PATHS solo verifican durante el solapamiento configurado.
+func loadKeyRing(ctx context.Context, db *mongo.Database, accessTokenTTL time.Duration) (*usecases.KeyRing, error) {
+	algorithm := getEnv("JWT_SIGNING_ALG", valueobjects.AlgorithmHS256)
+	cipher, err := loadSigningKeyCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	// Una clave reemplazada debe seguir verificando al menos lo que dura un access token
+	overlapHours, _ := strconv.ParseInt(getEnv("JWT_KEY_OVERLAP_HOURS", "0"), 10, 64)
+	overlap := time.Hour * time.Duration(overlapHours)
+	if overlap < accessTokenTTL {
+		overlap = accessTokenTTL
+	}
+	keyRing, err := usecases.LoadKeyRing(ctx, persistence.NewMongoSigningKeyRepository(db), cipher, func() (*valueobjects.SigningKey, error) {
+		return loadSigningKey(algorithm)
+	}, func() (*valueobjects.SigningKey, error) {
+		return security.GenerateSigningKey(algorithm, "")
+	}, overlap)
+	if err != nil {
+		return nil, err
+	}
+	if active := keyRing.Active(); active.Algorithm != algorithm {
+		log.Printf("Warning: stored active signing key %s is %s, JWT_SIGNING_ALG %s applies from the next rotation", active.ID, active.Algorithm, algorithm)
+	}
+
+	for _, path := range strings.Split(getEnv("JWT_VERIFICATION_KEY_PATHS", ""), ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		key, err := security.LoadSigningKey(path, "")
+		if err != nil {
+			return nil, err
+		}
+		if err := keyRing.AddVerificationKey(ctx, key, time.Now().Add(overlap)); err != nil {
+			return nil, err
+		}
+	}
+	return keyRing, nil
+}
+
+// loadSigningKey obtiene la clave activa inicial de firma de JWT, cuando todavía no hay una guardada.
+// Para algoritmos asimétricos sin JWT_PRIVATE_KEY_PATH se genera una clave nueva, que el key ring guarda.
+func loadSigningKey(algorithm string) (*valueobjects.SigningKey, error) {
+	kid := getEnv("JWT_KEY_ID", "")
+	if algorithm == valueobjects.AlgorithmHS256 {
+		if kid == "" {
+			kid = "default"
+		}
+		return valueobjects.NewHMACSigningKey(kid, []byte(getEnv("JWT_SECRET", "12454sd32"))), nil
+	}
+	if path := getEnv("JWT_PRIVATE_KEY_PATH", ""); path != "" {
+		key, err := security.LoadSigningKey(path, kid)
+		if err != nil {
+			return nil, err
+		}
+		if key.Algorithm != algorithm {
+			return nil, fmt.Errorf("key in %s is %s but JWT_SIGNING_ALG is %s", path, key.Algorithm, algorithm)
+		}
+		return key, nil
+	}
+	log.Printf("Warning: JWT_PRIVATE_KEY_PATH not set, generating a new %s signing key", algorithm)
+	return security.GenerateSigningKey(algorithm, kid)
+}
+
+// loadMFACipher crea el cifrador de los secretos TOTP. Cambiar MFA_ENCRYPTION_KEY invalida
+// los secretos ya registrados, por lo que debe mantenerse estable entre despliegues.
+func loadMFACipher() (*security.AESCipher, error) {
+	key := getEnv("MFA_ENCRYPTION_KEY", "")
+	if key == "" {
+		log.Println("Warning: MFA_ENCRYPTION_KEY not set, using JWT_SECRET to encrypt TOTP secrets")
+		key = getEnv("JWT_SECRET", "12454sd32")
+	}
+	return security.NewAESCipher(key)
+}
+
+// loadSigningKeyCipher crea el cifrador de las claves privadas de firma guardadas en mongo. Como
+// con MFA_ENCRYPTION_KEY, cambiar JWT_KEY_ENCRYPTION_KEY deja ilegibles las claves ya guardadas.
+func loadSigningKeyCipher() (*security.AESCipher, error) {
+	key := getEnv("JWT_KEY_ENCRYPTION_KEY", "")
+	if key == "" {
+		log.Println("Warning: JWT_KEY_ENCRYPTION_KEY not set, using JWT_SECRET to encrypt signing keys")
+		key = getEnv("JWT_SECRET", "12454sd32")
+	}
+	return security.NewAESCipher(key)
+}
+
+// loadNotifier envía los enlaces por SMTP si SMTP_HOST está configurado; si no, solo los escribe en el log
+func loadNotifier(port string) services.Notifier {
+	config := notification.MailNotifierConfig{
+		PasswordResetURL:     getEnv("PASSWORD_RESET_URL", "http://localhost:"+port+"/reset-password"),
+		EmailVerificationURL: getEnv("EMAIL_VERIFICATION_URL", "http://localhost:"+port+"/verify-email"),
+	}
+	smtpHost := getEnv("SMTP_HOST", "")
+	if smtpHost == "" {
+		log.Println("Warning: SMTP_HOST not set, notification links will only be logged")
+		return notification.NewLogNotifier(config)
+	}
+	sender := mail.NewSMTPSender(mail.SMTPConfig{
+		Host:     smtpHost,
+		Port:     getEnv("SMTP_PORT", "587"),
+		Username: getEnv("SMTP_USERNAME", ""),
+		Password: getEnv("SMTP_PASSWORD", ""),
+		From:     getEnv("SMTP_FROM", "no-reply@localhost"),
+	})
+	return notification.NewMailNotifier(sender, config)
+}
+
+// loadEmailRules reglas de canonicalización de emails; al cambiarlas la migración del arranque recalcula los existentes
+func loadEmailRules() valueobjects.EmailRules {
+	return valueobjects.EmailRules{
+		IDN:             getEnv("EMAIL_IDN", "true") == "true",
+		ProviderAliases: getEnv("EMAIL_PROVIDER_ALIASES", "false") == "true",
+	}
+}
+
+// loadLoginAttemptRepository LOGIN_ATTEMPT_STORE=memory guarda los contadores en el proceso;
+// solo es adecuado con una única instancia del servicio
+func loadLoginAttemptRepository(db *mongo.Database) repositories.LoginAttemptRepository {
+	if getEnv("LOGIN_ATTEMPT_STORE", "mongo") == "memory" {
+		return persistence.NewMemoryLoginAttemptRepository()
+	}
+	return persistence.NewMongoLoginAttemptRepository(db)
+}
+
+func loadLockoutPolicy() services.LockoutPolicy {
+	maxAccountFailures, _ := strconv.Atoi(getEnv("LOGIN_MAX_FAILURES", "5"))
+	maxIPFailures, _ := strconv.Atoi(getEnv("LOGIN_MAX_IP_FAILURES", "20"))
+	baseLockSeconds, _ := strconv.ParseInt(getEnv("LOGIN_LOCK_BASE_SECONDS", "30"), 10, 64)
+	maxLockMinutes, _ := strconv.ParseInt(getEnv("LOGIN_LOCK_MAX_MINUTES", "60"), 10, 64)
+	windowMinutes, _ := strconv.ParseInt(getEnv("LOGIN_FAILURE_WINDOW_MINUTES", "15"), 10, 64)
+	return services.LockoutPolicy{
+		MaxAccountFailures: maxAccountFailures,
+		MaxIPFailures:      maxIPFailures,
+		BaseLockDuration:   time.Second * time.Duration(baseLockSeconds),
+		MaxLockDuration:    time.Minute * time.Duration(maxLockMinutes),
+		FailureWindow:      time.Minute * time.Duration(windowMinutes),
+	}
+}
+
+// loadPasswordPolicy PASSWORD_MAX_LENGTH se cuenta en bytes y no debería superar 72 porque bcrypt no admite contraseñas más largas
+func loadPasswordPolicy() services.PasswordPolicy {
+	minLength, _ := strconv.Atoi(getEnv("PASSWORD_MIN_LENGTH", "8"))
+	maxLength, _ := strconv.Atoi(getEnv("PASSWORD_MAX_LENGTH", "64"))
+	minEntropyBits, _ := strconv.ParseFloat(getEnv("PASSWORD_MIN_ENTROPY_BITS", "35"), 64)
+	historySize, _ := strconv.Atoi(getEnv("PASSWORD_HISTORY_SIZE", "5"))
+	return services.PasswordPolicy{
+		MinLength:        minLength,
+		MaxLength:        maxLength,
+		RequireUppercase: getEnv("PASSWORD_REQUIRE_UPPERCASE", "false") == "true",
+		RequireLowercase: getEnv("PASSWORD_REQUIRE_LOWERCASE", "false") == "true",
+		RequireDigit:     getEnv("PASSWORD_REQUIRE_DIGIT", "false") == "true",
+		RequireSymbol:    getEnv("PASSWORD_REQUIRE_SYMBOL", "false") == "true",
+		ForbidEmail:      getEnv("PASSWORD_FORBID_EMAIL", "true") == "true",
+		ForbidCommon:     getEnv("PASSWORD_FORBID_COMMON", "true") == "true",
+		MinEntropyBits:   minEntropyBits,
+		HistorySize:      historySize,
+	}
+}
+
+// loadBreachedPasswordChecker PASSWORD_BREACH_CHECK=file busca en una copia local de Pwned Passwords
+// ordenada por hash; api consulta la API de rangos en PASSWORD_BREACH_API_URL; none desactiva la comprobación
+func loadBreachedPasswordChecker() (services.BreachedPasswordChecker, error) {
+	switch mode := getEnv("PASSWORD_BREACH_CHECK", "none"); mode {
+	case "none":
+		return nil, nil
+	case "file":
+		return security.NewPwnedFileChecker(getEnv("PASSWORD_BREACH_FILE", "pwned-passwords-sha1-ordered-by-hash.txt"))
+	case "api":
+		return security.NewPwnedRangeChecker(getEnv("PASSWORD_BREACH_API_URL", "https://api.pwnedpasswords.com")), nil
+	default:
+		return nil, fmt.Errorf("unknown PASSWORD_BREACH_CHECK %q", mode)
+	}
+}
+
+// loadPasswordHasher PASSWORD_HASH_ALGORITHM elige el algoritmo de los hashes nuevos; los hashes de
+// cualquier algoritmo soportado siguen verificando y se regeneran con el actual en el siguiente login.
+// Falla si los parámetros ARGON2_* no son números o están fuera de rango.
+func loadPasswordHasher() (*security.MultiHasher, error) {
+	params := security.DefaultArgon2Params
+	for _, setting := range []struct {
+		name    string
+		bitSize int
+		set     func(value uint64)
+	}{
+		{"ARGON2_MEMORY_KB", 32, func(value uint64) { params.Memory = uint32(value) }},
+		{"ARGON2_ITERATIONS", 32, func(value uint64) { params.Iterations = uint32(value) }},
+		{"ARGON2_PARALLELISM", 8, func(value uint64) { params.Parallelism = uint8(value) }},
+	} {
+		raw := getEnv(setting.name, "")
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseUint(raw, 10, setting.bitSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", setting.name, err)
+		}
+		setting.set(value)
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	bcryptCost, _ := strconv.Atoi(getEnv("BCRYPT_COST", "10"))
+	return security.NewMultiHasher(
+		getEnv("PASSWORD_HASH_ALGORITHM", security.HashAlgorithmArgon2id),
+		security.NewArgon2Hasher(params),
+		security.NewBcryptHasherWithCost(bcryptCost),
+	), nil
+}
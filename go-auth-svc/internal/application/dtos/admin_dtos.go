@@ -0,0 +1,40 @@
+package dtos
+
+import "time"
+
+// ListUsersRequest filtros y paginación del listado de usuarios; las fechas se reciben en RFC 3339.
+// Cursor es el next_cursor de la respuesta anterior y debe enviarse con el mismo sort y order.
+type ListUsersRequest struct {
+	EmailPrefix   string `query:"email_prefix"`
+	Role          string `query:"role"`
+	Active        *bool  `query:"active"`
+	EmailVerified *bool  `query:"email_verified"`
+	CreatedFrom   string `query:"created_from"`
+	CreatedTo     string `query:"created_to"`
+	UpdatedFrom   string `query:"updated_from"`
+	UpdatedTo     string `query:"updated_to"`
+	Sort          string `query:"sort" validate:"omitempty,oneof=created_at updated_at email"`
+	Order         string `query:"order" validate:"omitempty,oneof=asc desc"`
+	Cursor        string `query:"cursor"`
+	Limit         int    `query:"limit" validate:"min=0,max=100"`
+}
+
+type UpdateUserRolesRequest struct {
+	Roles []string `json:"roles" validate:"required,min=1"`
+}
+
+// AdminUserResponse vista de un usuario para la administración, con las fechas de la cuenta
+type AdminUserResponse struct {
+	UserResponse
+	PasswordResetRequired bool      `json:"password_reset_required"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+type UserListResponse struct {
+	Users []AdminUserResponse `json:"users"`
+	// Total usuarios que cumplen el filtro, sumando todas las páginas
+	Total      int64  `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Limit      int    `json:"limit"`
+}
@@ -1,26 +1,54 @@
 package dtos
 
+import "poc-auth-svc/internal/domain/valueobjects"
+
 type RegisterRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Email string `json:"email" validate:"required,email"`
+	// Password las reglas de longitud y complejidad las aplica la PasswordPolicy del dominio
+	Password string `json:"password" validate:"required"`
 	Role     string `json:"role,omitempty"`
+	// Roles elegir roles distintos del rol por defecto exige un bearer token con el permiso roles:grant
+	Roles []string `json:"roles,omitempty"`
+	// Locale idioma preferido; si no se envía el handler usa el negociado con Accept-Language
+	Locale string `json:"locale,omitempty" validate:"omitempty,oneof=es en pt"`
+	// GrantorToken la completa el handler con el bearer token opcional de quien registra al usuario
+	GrantorToken string `json:"-"`
 }
 
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
+	// ClientIP la completa el handler con la IP de origen de la petición
+	ClientIP string `json:"-"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
+// AuthResponse si el usuario tiene segundo factor, el login solo devuelve MFARequired y MFAToken
+// y los tokens se emiten al completar /mfa/verify
 type AuthResponse struct {
-	Token string        `json:"token"`
-	User  *UserResponse `json:"user"`
+	Token        string        `json:"token,omitempty"`
+	RefreshToken string        `json:"refresh_token,omitempty"`
+	User         *UserResponse `json:"user,omitempty"`
+	MFARequired  bool          `json:"mfa_required,omitempty"`
+	MFAToken     string        `json:"mfa_token,omitempty"`
+	// EmailVerificationRequired el registro no inicia sesión hasta que el usuario verifique su email
+	EmailVerificationRequired bool `json:"email_verification_required,omitempty"`
 }
 
 type UserResponse struct {
-	ID       string `json:"id"`
-	Email    string `json:"email"`
-	Role     string `json:"role"`
-	IsActive bool   `json:"is_active"`
+	ID            string   `json:"id"`
+	Email         string   `json:"email"`
+	Role          string   `json:"role"`
+	IsActive      bool     `json:"is_active"`
+	MFAEnabled    bool     `json:"mfa_enabled"`
+	EmailVerified bool     `json:"email_verified"`
+	Roles         []string `json:"roles"`
+	Locale        string   `json:"locale,omitempty"`
+	// Permissions solo se informa al validar un token
+	Permissions []string `json:"permissions,omitempty"`
 }
 
 type ValidateResponse struct {
@@ -28,3 +56,12 @@ type ValidateResponse struct {
 	User   *UserResponse          `json:"user,omitempty"`
 	Claims map[string]interface{} `json:"claims,omitempty"`
 }
+
+type JWKSResponse struct {
+	Keys []valueobjects.JWK `json:"keys"`
+}
+
+// UpdateLocaleRequest idioma preferido del usuario; vacío vuelve a negociarlo con Accept-Language
+type UpdateLocaleRequest struct {
+	Locale string `json:"locale" validate:"omitempty,oneof=es en pt"`
+}
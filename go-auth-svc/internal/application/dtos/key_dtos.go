@@ -0,0 +1,11 @@
+package dtos
+
+import "time"
+
+type SigningKeyResponse struct {
+	KeyID     string     `json:"kid"`
+	Algorithm string     `json:"alg"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	RetiresAt *time.Time `json:"retires_at,omitempty"`
+}
@@ -0,0 +1,25 @@
+package dtos
+
+// MFAVerifyRequest completa el login con el token de desafío y un código TOTP o de recuperación
+type MFAVerifyRequest struct {
+	MFAToken     string `json:"mfa_token" validate:"required"`
+	Code         string `json:"code,omitempty" validate:"required_without=RecoveryCode"`
+	RecoveryCode string `json:"recovery_code,omitempty"`
+	// ClientIP la completa el handler con la IP de origen de la petición
+	ClientIP string `json:"-"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type MFAEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	// QRCode imagen PNG de la URI otpauth:// como data URI
+	QRCode string `json:"qr_code"`
+}
+
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
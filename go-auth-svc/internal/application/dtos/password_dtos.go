@@ -0,0 +1,17 @@
+package dtos
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,nefield=CurrentPassword"`
+	// LogoutOtherSessions cierra las demás sesiones; la actual continúa con los tokens que devuelve la respuesta
+	LogoutOtherSessions bool `json:"logout_other_sessions"`
+}
@@ -0,0 +1,94 @@
+package dtos
+
+import "time"
+
+// Las opciones y respuestas siguen la serialización JSON de WebAuthn nivel 3 (PublicKeyCredential.toJSON):
+// los campos binarios viajan en base64url sin padding
+
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WebAuthnUserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type PublicKeyCredentialParameters struct {
+	Type      string `json:"type"`
+	Algorithm int64  `json:"alg"`
+}
+
+type PublicKeyCredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+type PublicKeyCredentialCreationOptions struct {
+	Challenge              string                          `json:"challenge"`
+	RelyingParty           RelyingPartyEntity              `json:"rp"`
+	User                   WebAuthnUserEntity              `json:"user"`
+	PubKeyCredParams       []PublicKeyCredentialParameters `json:"pubKeyCredParams"`
+	Timeout                int64                           `json:"timeout"`
+	ExcludeCredentials     []PublicKeyCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection          `json:"authenticatorSelection"`
+	Attestation            string                          `json:"attestation"`
+}
+
+type PublicKeyCredentialRequestOptions struct {
+	Challenge        string                          `json:"challenge"`
+	RelyingPartyID   string                          `json:"rpId"`
+	Timeout          int64                           `json:"timeout"`
+	AllowCredentials []PublicKeyCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                          `json:"userVerification"`
+}
+
+type AttestationResponse struct {
+	ClientDataJSON    string   `json:"clientDataJSON" validate:"required"`
+	AttestationObject string   `json:"attestationObject" validate:"required"`
+	Transports        []string `json:"transports,omitempty"`
+}
+
+type WebAuthnRegisterFinishRequest struct {
+	// Name nombre que el usuario le da a la passkey para reconocerla en el listado
+	Name     string              `json:"name,omitempty"`
+	ID       string              `json:"id" validate:"required"`
+	Type     string              `json:"type" validate:"required,eq=public-key"`
+	Response AttestationResponse `json:"response"`
+}
+
+type WebAuthnLoginBeginRequest struct {
+	Email string `json:"email,omitempty" validate:"omitempty,email"`
+}
+
+type AssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON" validate:"required"`
+	AuthenticatorData string `json:"authenticatorData" validate:"required"`
+	Signature         string `json:"signature" validate:"required"`
+	UserHandle        string `json:"userHandle,omitempty"`
+}
+
+type WebAuthnLoginFinishRequest struct {
+	ID       string            `json:"id" validate:"required"`
+	Type     string            `json:"type" validate:"required,eq=public-key"`
+	Response AssertionResponse `json:"response"`
+}
+
+type WebAuthnCredentialResponse struct {
+	ID                string     `json:"id"`
+	CredentialID      string     `json:"credential_id"`
+	Name              string     `json:"name"`
+	AAGUID            string     `json:"aaguid"`
+	AttestationFormat string     `json:"attestation_format"`
+	SignCount         uint32     `json:"sign_count"`
+	CreatedAt         time.Time  `json:"created_at"`
+	LastUsedAt        *time.Time `json:"last_used_at,omitempty"`
+}
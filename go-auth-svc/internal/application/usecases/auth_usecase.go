@@ -2,133 +2,325 @@ package usecases
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"log"
+	"slices"
 	"time"
 
 	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
 	"poc-auth-svc/internal/domain/services"
 	"poc-auth-svc/internal/domain/valueobjects"
-
-	"github.com/golang-jwt/jwt"
 )
 
 type AuthUseCase interface {
 	Register(ctx context.Context, req *dtos.RegisterRequest) (*dtos.AuthResponse, error)
 	Login(ctx context.Context, req *dtos.LoginRequest) (*dtos.AuthResponse, error)
+	// VerifyMFA completa un login con segundo factor y emite los tokens de la sesión
+	VerifyMFA(ctx context.Context, req *dtos.MFAVerifyRequest) (*dtos.AuthResponse, error)
 	ValidateToken(ctx context.Context, tokenString string) (*dtos.ValidateResponse, error)
+	Refresh(ctx context.Context, req *dtos.RefreshRequest) (*dtos.AuthResponse, error)
+	Logout(ctx context.Context, tokenString string) error
+	LogoutAll(ctx context.Context, tokenString string) error
+	JWKS(ctx context.Context) *dtos.JWKSResponse
+	UpdateLocale(ctx context.Context, userID string, req *dtos.UpdateLocaleRequest) (*dtos.UserResponse, error)
 }
 
 type authUseCase struct {
-	authService services.AuthService
-	jwt         JwtWrapper
-}
-
-type JwtWrapper struct {
-	SecretKey       string
-	Issuer          string
-	ExpirationHours int64
+	authService       services.AuthService
+	oauthService      services.OAuthService
+	refreshService    services.RefreshTokenService
+	revocationService services.TokenRevocationService
+	mfaService        services.MFAService
+	authorization     services.AuthorizationService
+	notifier          services.Notifier
+	policy            services.AuthPolicy
+	jwt               JwtWrapper
 }
 
-func NewAuthUseCase(authService services.AuthService, config JwtWrapper) AuthUseCase {
+func NewAuthUseCase(authService services.AuthService, oauthService services.OAuthService, refreshService services.RefreshTokenService, revocationService services.TokenRevocationService, mfaService services.MFAService, authorization services.AuthorizationService, notifier services.Notifier, policy services.AuthPolicy, config JwtWrapper) AuthUseCase {
 	return &authUseCase{
-		authService: authService,
-		jwt:         config,
+		authService:       authService,
+		oauthService:      oauthService,
+		refreshService:    refreshService,
+		revocationService: revocationService,
+		mfaService:        mfaService,
+		authorization:     authorization,
+		notifier:          notifier,
+		policy:            policy,
+		jwt:               config,
 	}
 }
 
 // Login implements AuthUseCase.
 func (uc *authUseCase) Login(ctx context.Context, req *dtos.LoginRequest) (*dtos.AuthResponse, error) {
-	user, err := uc.authService.Login(ctx, req.Email, req.Password)
+	user, err := uc.authService.Login(ctx, req.Email, req.Password, req.ClientIP)
 	if err != nil {
 		return nil, err
 	}
-	token, err := uc.generateToken(user.ID, user.Email, user.Role)
+	if user.MFAEnabled {
+		mfaToken, err := uc.jwt.signWithType(uc.jwt.newMFAChallengeClaims(user), tokenTypeMFAChallenge)
+		if err != nil {
+			return nil, err
+		}
+		return &dtos.AuthResponse{MFARequired: true, MFAToken: mfaToken}, nil
+	}
+	return uc.issueTokens(ctx, user)
+}
+
+// VerifyMFA implements AuthUseCase.
+func (uc *authUseCase) VerifyMFA(ctx context.Context, req *dtos.MFAVerifyRequest) (*dtos.AuthResponse, error) {
+	errChallenge := err_domain.New(err_domain.MFAChallengeInvalid)
+	claims := &valueobjects.MFAChallengeClaims{}
+	if err := uc.jwt.parse(req.MFAToken, claims, tokenTypeMFAChallenge); err != nil {
+		return nil, errChallenge
+	}
+	revoked, err := uc.revocationService.IsRevoked(ctx, claims.Id, claims.UserID, time.Unix(claims.IssuedAt, 0))
 	if err != nil {
 		return nil, err
 	}
-	return &dtos.AuthResponse{
-		Token: token,
-		User: &dtos.UserResponse{
-			ID:       user.ID,
-			Email:    user.Email,
-			Role:     user.Role,
-			IsActive: user.IsActive,
-		},
-	}, nil
+	if revoked {
+		return nil, errChallenge
+	}
+	user, err := uc.authService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, errChallenge
+	}
+	// La cuenta pudo cambiar entre el desafío y el segundo factor
+	if err := uc.authService.CheckLoginPolicy(user); err != nil {
+		return nil, err
+	}
+	if err := uc.mfaService.VerifyLogin(ctx, user, req.Code, req.RecoveryCode, req.ClientIP); err != nil {
+		return nil, err
+	}
+	// El desafío es de un solo uso: se revoca al completarlo
+	if err := uc.revocationService.RevokeToken(ctx, claims.Id, time.Unix(claims.ExpiresAt, 0)); err != nil {
+		return nil, err
+	}
+	return uc.issueTokens(ctx, user)
 }
 
 // Register implements AuthUseCase.
 func (uc *authUseCase) Register(ctx context.Context, req *dtos.RegisterRequest) (*dtos.AuthResponse, error) {
-	user, err := uc.authService.Register(ctx, req.Email, req.Password, req.Role)
-	if err != nil {
+	roles := req.Roles
+	if req.Role != "" {
+		roles = append([]string{req.Role}, roles...)
+	}
+	if err := uc.authorizeRoleGrant(ctx, req.GrantorToken, roles); err != nil {
 		return nil, err
 	}
-	token, err := uc.generateToken(user.ID, user.Email, user.Role)
+	user, err := uc.authService.Register(ctx, req.Email, req.Password, roles, req.Locale)
 	if err != nil {
 		return nil, err
 	}
-	return &dtos.AuthResponse{
-		Token: token,
-		User: &dtos.UserResponse{
-			ID:       user.ID,
-			Email:    user.Email,
-			Role:     user.Role,
-			IsActive: user.IsActive,
-		},
-	}, nil
+	// La cuenta ya existe: si el envío falla el usuario puede pedir otro enlace con /email/resend
+	if err := sendEmailVerification(ctx, uc.notifier, uc.jwt, user); err != nil {
+		log.Printf("Failed to send email verification to %s: %v", user.Email, err)
+	}
+	if uc.policy.RequireVerifiedEmail {
+		return &dtos.AuthResponse{User: newUserResponse(user), EmailVerificationRequired: true}, nil
+	}
+	return uc.issueTokens(ctx, user)
+}
+
+// authorizeRoleGrant el registro público solo puede usar el rol por defecto; cualquier otro rol exige
+// que quien registra presente un token de un usuario con el permiso roles:grant y con todos los
+// permisos de los roles que otorga
+func (uc *authUseCase) authorizeRoleGrant(ctx context.Context, grantorToken string, roles []string) error {
+	if !slices.ContainsFunc(roles, func(role string) bool { return role != entities.RoleUser }) {
+		return nil
+	}
+	if grantorToken == "" {
+		return services.ErrPermissionDenied
+	}
+	claims, err := uc.parseToken(ctx, grantorToken)
+	if err != nil || claims.UserID == "" {
+		return services.ErrPermissionDenied
+	}
+	grantor, err := uc.authService.GetUserByID(ctx, claims.UserID)
+	if err != nil || !grantor.IsActive {
+		return services.ErrPermissionDenied
+	}
+	return uc.authorization.AuthorizeRoleGrant(ctx, grantor, roles)
 }
 
 // ValidateToken implements AuthUseCase.
 func (uc *authUseCase) ValidateToken(ctx context.Context, tokenString string) (*dtos.ValidateResponse, error) {
-	fmt.Println("usecase secret: " + uc.jwt.SecretKey)
-	fmt.Println(uc.jwt.Issuer)
-	fmt.Println(uc.jwt.ExpirationHours)
-	token, err := jwt.ParseWithClaims(tokenString, &valueobjects.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(uc.jwt.SecretKey), nil
-	})
+	claims, err := uc.parseToken(ctx, tokenString)
 	if err != nil {
 		return &dtos.ValidateResponse{Valid: false}, err
 	}
-	if claims, ok := token.Claims.(*valueobjects.JWTClaims); ok && token.Valid {
-		// Opcionalmente verificar si el usuario aún existe y está activo
-		user, err := uc.authService.GetUserByID(ctx, claims.UserID)
-		if err != nil || !user.IsActive {
+	// Tokens de service account: se verifica que el cliente siga activo
+	if claims.UserID == "" && claims.ClientID != "" {
+		if _, err := uc.oauthService.GetClient(ctx, claims.ClientID); err != nil {
 			return &dtos.ValidateResponse{Valid: false}, nil
 		}
-
 		return &dtos.ValidateResponse{
 			Valid: true,
-			User: &dtos.UserResponse{
-				ID:       user.ID,
-				Email:    user.Email,
-				Role:     user.Role,
-				IsActive: user.IsActive,
-			},
 			Claims: map[string]interface{}{
-				"user_id": claims.UserID,
-				"email":   claims.Email,
-				"role":    claims.Role,
-				//"exp":     claims.ExpiresAt.Unix(),
+				"sub":       claims.Subject,
+				"client_id": claims.ClientID,
+				"jti":       claims.Id,
+				"scope":     claims.Scope,
+				"iss":       claims.Issuer,
+				"iat":       claims.IssuedAt,
+				"exp":       claims.ExpiresAt,
 			},
 		}, nil
 	}
-	return &dtos.ValidateResponse{Valid: false}, nil
-}
+	// Opcionalmente verificar si el usuario aún existe y está activo
+	user, err := uc.authService.GetUserByID(ctx, claims.UserID)
+	if err != nil || !user.IsActive {
+		return &dtos.ValidateResponse{Valid: false}, nil
+	}
+	// Los permisos se resuelven de nuevo para que un cambio de roles aplique sin esperar a que expire el token
+	permissions, err := uc.authorization.Permissions(ctx, user.RoleNames())
+	if err != nil {
+		return &dtos.ValidateResponse{Valid: false}, err
+	}
+	userResponse := newUserResponse(user)
+	userResponse.Permissions = permissions
 
-func (uc *authUseCase) generateToken(userID, email, role string) (signedToken string, err error) {
-	claims := &valueobjects.JWTClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Local().Add(time.Hour * time.Duration(uc.jwt.ExpirationHours)).Unix(),
-			Issuer:    uc.jwt.Issuer,
+	return &dtos.ValidateResponse{
+		Valid: true,
+		User:  userResponse,
+		Claims: map[string]interface{}{
+			"user_id":     claims.UserID,
+			"email":       claims.Email,
+			"role":        claims.Role,
+			"roles":       claims.Roles,
+			"permissions": claims.Permissions,
+			"jti":         claims.Id,
+			"scope":       claims.Scope,
+			"client_id":   claims.ClientID,
+			"aud":         claims.Audience,
+			"iss":         claims.Issuer,
+			"iat":         claims.IssuedAt,
+			"exp":         claims.ExpiresAt,
 		},
+	}, nil
+}
+
+// Logout implements AuthUseCase.
+func (uc *authUseCase) Logout(ctx context.Context, tokenString string) error {
+	claims, err := uc.parseToken(ctx, tokenString)
+	if err != nil {
+		return err
+	}
+	if err := uc.revocationService.RevokeToken(ctx, claims.Id, time.Unix(claims.ExpiresAt, 0)); err != nil {
+		return err
+	}
+	if claims.SessionID != "" {
+		return uc.refreshService.RevokeFamily(ctx, claims.SessionID)
+	}
+	return nil
+}
+
+// LogoutAll implements AuthUseCase.
+func (uc *authUseCase) LogoutAll(ctx context.Context, tokenString string) error {
+	claims, err := uc.parseToken(ctx, tokenString)
+	if err != nil {
+		return err
+	}
+	return uc.revocationService.RevokeAllForUser(ctx, claims.UserID)
+}
+
+// JWKS implements AuthUseCase.
+func (uc *authUseCase) JWKS(ctx context.Context) *dtos.JWKSResponse {
+	response := &dtos.JWKSResponse{Keys: []valueobjects.JWK{}}
+	for _, key := range uc.jwt.KeyRing.PublicKeys() {
+		if jwk, err := valueobjects.NewJWK(key); err == nil {
+			response.Keys = append(response.Keys, jwk)
+		}
+	}
+	return response
+}
+
+// Refresh implements AuthUseCase.
+func (uc *authUseCase) Refresh(ctx context.Context, req *dtos.RefreshRequest) (*dtos.AuthResponse, error) {
+	plainToken, refreshToken, err := uc.refreshService.Rotate(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, err
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err = token.SignedString([]byte(uc.jwt.SecretKey))
+	user, err := uc.authService.GetUserByID(ctx, refreshToken.UserID)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, err_domain.New(err_domain.UserInactive)
+	}
+	token, err := uc.jwt.signAccessToken(ctx, user, refreshToken.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	return newAuthResponse(user, token, plainToken), nil
+}
+
+// issueTokens genera el access token y el refresh token de una nueva sesión
+func (uc *authUseCase) issueTokens(ctx context.Context, user *entities.User) (*dtos.AuthResponse, error) {
+	return issueSession(ctx, uc.refreshService, uc.jwt, user)
+}
+
+// issueSession emite los tokens de una sesión nueva; la comparten todos los mecanismos de login
+func issueSession(ctx context.Context, refreshService services.RefreshTokenService, jwtWrapper JwtWrapper, user *entities.User) (*dtos.AuthResponse, error) {
+	plainToken, refreshToken, err := refreshService.Issue(ctx, user.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwtWrapper.signAccessToken(ctx, user, refreshToken.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	return newAuthResponse(user, token, plainToken), nil
+}
+
+// UpdateLocale implements AuthUseCase.
+func (uc *authUseCase) UpdateLocale(ctx context.Context, userID string, req *dtos.UpdateLocaleRequest) (*dtos.UserResponse, error) {
+	user, err := uc.authService.UpdateLocale(ctx, userID, req.Locale)
+	if err != nil {
+		return nil, err
+	}
+	return newUserResponse(user), nil
+}
+
+func newAuthResponse(user *entities.User, token, refreshToken string) *dtos.AuthResponse {
+	return &dtos.AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		User:         newUserResponse(user),
+	}
+}
+
+func newUserResponse(user *entities.User) *dtos.UserResponse {
+	return &dtos.UserResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		Role:          user.RoleNames()[0],
+		IsActive:      user.IsActive,
+		MFAEnabled:    user.MFAEnabled,
+		EmailVerified: user.EmailVerified,
+		Roles:         user.RoleNames(),
+		Locale:        user.Locale,
+	}
+}
+
+// parseToken verifica firma, expiración y que el token no haya sido revocado
+func (uc *authUseCase) parseToken(ctx context.Context, tokenString string) (*valueobjects.JWTClaims, error) {
+	claims := &valueobjects.JWTClaims{}
+	if err := uc.jwt.parse(tokenString, claims, tokenTypeAccess); err != nil {
+		return nil, err_domain.Wrap(err_domain.AccessTokenInvalid, err)
+	}
+	// Todo access token es de un usuario o de un cliente; sin ninguno de los dos no identifica a nadie
+	if claims.UserID == "" && claims.ClientID == "" {
+		return nil, err_domain.Wrap(err_domain.AccessTokenInvalid, errors.New("token has no subject"))
+	}
+	revoked, err := uc.revocationService.IsRevoked(ctx, claims.Id, claims.UserID, time.Unix(claims.IssuedAt, 0))
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, err_domain.Wrap(err_domain.AccessTokenInvalid, errors.New("token has been revoked"))
 	}
-	return signedToken, nil
+	return claims, nil
 }
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/services"
+	"poc-auth-svc/internal/domain/valueobjects"
+)
+
+// emailVerificationResendTimeout límite para reenviar el enlace fuera del ciclo de la petición
+const emailVerificationResendTimeout = 30 * time.Second
+
+type EmailVerificationUseCase interface {
+	VerifyEmail(ctx context.Context, req *dtos.VerifyEmailRequest) (*dtos.UserResponse, error)
+	// ResendVerification reenvía el enlace en segundo plano; la respuesta no revela si el email existe
+	ResendVerification(ctx context.Context, req *dtos.ResendVerificationRequest)
+}
+
+type emailVerificationUseCase struct {
+	verificationService services.EmailVerificationService
+	notifier            services.Notifier
+	jwt                 JwtWrapper
+}
+
+func NewEmailVerificationUseCase(verificationService services.EmailVerificationService, notifier services.Notifier, jwtWrapper JwtWrapper) EmailVerificationUseCase {
+	return &emailVerificationUseCase{
+		verificationService: verificationService,
+		notifier:            notifier,
+		jwt:                 jwtWrapper,
+	}
+}
+
+// VerifyEmail implements EmailVerificationUseCase.
+func (uc *emailVerificationUseCase) VerifyEmail(ctx context.Context, req *dtos.VerifyEmailRequest) (*dtos.UserResponse, error) {
+	claims := &valueobjects.EmailVerificationClaims{}
+	if err := uc.jwt.parse(req.Token, claims, tokenTypeEmailVerification); err != nil {
+		return nil, services.ErrEmailVerificationTokenInvalid
+	}
+	user, err := uc.verificationService.ConfirmEmail(ctx, claims.UserID, claims.Email)
+	if err != nil {
+		return nil, err
+	}
+	return newUserResponse(user), nil
+}
+
+// ResendVerification implements EmailVerificationUseCase.
+func (uc *emailVerificationUseCase) ResendVerification(ctx context.Context, req *dtos.ResendVerificationRequest) {
+	email := req.Email
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), emailVerificationResendTimeout)
+		defer cancel()
+		user, err := uc.verificationService.PendingVerification(ctx, email)
+		if err != nil || user == nil {
+			return
+		}
+		if err := sendEmailVerification(ctx, uc.notifier, uc.jwt, user); err != nil {
+			log.Printf("Failed to resend email verification: %v", err)
+		}
+	}()
+}
+
+// sendEmailVerification firma el enlace de verificación y lo entrega por el Notifier
+func sendEmailVerification(ctx context.Context, notifier services.Notifier, jwtWrapper JwtWrapper, user *entities.User) error {
+	claims := jwtWrapper.newEmailVerificationClaims(user)
+	token, err := jwtWrapper.signWithType(claims, tokenTypeEmailVerification)
+	if err != nil {
+		return err
+	}
+	return notifier.SendEmailVerification(ctx, user, token, time.Unix(claims.ExpiresAt, 0))
+}
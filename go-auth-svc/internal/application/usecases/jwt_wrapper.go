@@ -0,0 +1,170 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/valueobjects"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+// Valores del header "typ": cada propósito usa un tipo distinto para que un token
+// emitido para un fin (p. ej. el desafío MFA o el ID token) no pueda usarse como access token
+const (
+	// tokenTypeAccess tipo de los access tokens según RFC 9068
+	tokenTypeAccess            = "at+jwt"
+	tokenTypeIDToken           = "JWT"
+	tokenTypeMFAChallenge      = "mfa-challenge+jwt"
+	tokenTypeEmailVerification = "email-verification+jwt"
+)
+
+const (
+	// mfaChallengeTTL tiempo que tiene el usuario para ingresar el segundo factor después del login
+	mfaChallengeTTL = 5 * time.Minute
+	// emailVerificationTTL vigencia del enlace de verificación de email
+	emailVerificationTTL = 24 * time.Hour
+)
+
+// PermissionResolver resuelve los permisos efectivos de los roles para el claim permissions
+type PermissionResolver interface {
+	Permissions(ctx context.Context, roles []string) ([]string, error)
+}
+
+type JwtWrapper struct {
+	KeyRing         *KeyRing
+	Issuer          string
+	ExpirationHours int64
+	// Permissions si es nil los access tokens no incluyen el claim permissions
+	Permissions PermissionResolver
+}
+
+// newAccessClaims claims base de un access token de usuario con jti, iat y exp
+func (w JwtWrapper) newAccessClaims(ctx context.Context, user *entities.User) (*valueobjects.JWTClaims, error) {
+	now := time.Now()
+	roles := user.RoleNames()
+	claims := &valueobjects.JWTClaims{
+		UserID: user.ID,
+		Email:  user.Email,
+		Role:   roles[0],
+		Roles:  roles,
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Local().Add(w.accessTokenTTL()).Unix(),
+			Issuer:    w.Issuer,
+		},
+	}
+	if w.Permissions != nil {
+		permissions, err := w.Permissions.Permissions(ctx, roles)
+		if err != nil {
+			return nil, err
+		}
+		claims.Permissions = permissions
+	}
+	return claims, nil
+}
+
+// signAccessToken firma el access token de usuario ligado a la sesión (familia de refresh tokens)
+func (w JwtWrapper) signAccessToken(ctx context.Context, user *entities.User, sessionID string) (string, error) {
+	claims, err := w.newAccessClaims(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	claims.SessionID = sessionID
+	return w.sign(claims)
+}
+
+// newClientClaims claims de un access token de service account: sub y client_id son el cliente
+func (w JwtWrapper) newClientClaims(client *entities.Client, scope string) *valueobjects.JWTClaims {
+	now := time.Now()
+	return &valueobjects.JWTClaims{
+		ClientID: client.ID,
+		Scope:    scope,
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			Subject:   client.ID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(w.accessTokenTTL()).Unix(),
+			Issuer:    w.Issuer,
+		},
+	}
+}
+
+// newMFAChallengeClaims claims del desafío de segundo factor; el jti permite revocarlo al usarlo
+func (w JwtWrapper) newMFAChallengeClaims(user *entities.User) *valueobjects.MFAChallengeClaims {
+	now := time.Now()
+	return &valueobjects.MFAChallengeClaims{
+		UserID: user.ID,
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(mfaChallengeTTL).Unix(),
+			Issuer:    w.Issuer,
+		},
+	}
+}
+
+func (w JwtWrapper) newEmailVerificationClaims(user *entities.User) *valueobjects.EmailVerificationClaims {
+	now := time.Now()
+	return &valueobjects.EmailVerificationClaims{
+		UserID: user.ID,
+		Email:  user.Email,
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(emailVerificationTTL).Unix(),
+			Issuer:    w.Issuer,
+		},
+	}
+}
+
+func (w JwtWrapper) accessTokenTTL() time.Duration {
+	return time.Hour * time.Duration(w.ExpirationHours)
+}
+
+// sign firma un access token con la clave activa del key ring e incluye su kid en el header
+func (w JwtWrapper) sign(claims jwt.Claims) (string, error) {
+	return w.signWithType(claims, tokenTypeAccess)
+}
+
+func (w JwtWrapper) signWithType(claims jwt.Claims, tokenType string) (string, error) {
+	key := w.KeyRing.Active()
+	token := jwt.NewWithClaims(key.SigningMethod(), claims)
+	token.Header["typ"] = tokenType
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.PrivateKey)
+}
+
+// parse verifica firma, expiración y tipo del token y decodifica sus claims
+func (w JwtWrapper) parse(tokenString string, claims jwt.Claims, tokenType string) error {
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != tokenType {
+			return nil, fmt.Errorf("unexpected token type %q", typ)
+		}
+		key := w.KeyRing.Active()
+		if kid, ok := token.Header["kid"].(string); ok {
+			if key, ok = w.KeyRing.VerificationKey(kid); !ok {
+				return nil, fmt.Errorf("unknown key id %s", kid)
+			}
+		}
+		// Se exige el algoritmo de la clave para evitar ataques de confusión de algoritmo
+		if token.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+		return key.PublicKey, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !token.Valid {
+		return errors.New("invalid token")
+	}
+	return nil
+}
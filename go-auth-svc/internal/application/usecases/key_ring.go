@@ -0,0 +1,333 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+	"poc-auth-svc/internal/domain/services"
+	"poc-auth-svc/internal/domain/valueobjects"
+)
+
+const (
+	// keyRingReloadInterval tiempo mínimo entre recargas provocadas por un kid desconocido, para que
+	// tokens con kids inventados no consulten la base en cada request
+	keyRingReloadInterval = 30 * time.Second
+	keyRingReloadTimeout  = 5 * time.Second
+)
+
+var (
+	// ErrKeyNotFound se devuelve cuando el kid no pertenece al key ring
+	ErrKeyNotFound = err_domain.New(err_domain.SigningKeyNotFound)
+	// ErrActiveKeyRetirement se devuelve al intentar retirar la clave activa sin rotar antes
+	ErrActiveKeyRetirement = err_domain.New(err_domain.ActiveSigningKeyRetirement)
+	// errNoActiveKey el repositorio no tiene clave activa; ocurre brevemente mientras otra réplica rota
+	errNoActiveKey = errors.New("no active signing key stored")
+)
+
+// KeyGenerator crea una nueva clave de firma al rotar
+type KeyGenerator func() (*valueobjects.SigningKey, error)
+
+// KeyStatus estado de una clave dentro del key ring
+type KeyStatus string
+
+const (
+	KeyStatusActive    KeyStatus = entities.SigningKeyStatusActive
+	KeyStatusVerifying KeyStatus = entities.SigningKeyStatusVerifying
+)
+
+// KeyInfo metadatos públicos de una clave del key ring
+type KeyInfo struct {
+	ID        string
+	Algorithm string
+	Status    KeyStatus
+	CreatedAt time.Time
+	RetiresAt *time.Time
+}
+
+type ringKey struct {
+	key       *valueobjects.SigningKey
+	createdAt time.Time
+	retiresAt *time.Time
+}
+
+// KeyRing mantiene una clave activa de firma y N claves solo de verificación con fecha de retiro.
+// Las claves se guardan cifradas en el repositorio para que todas las réplicas firmen y publiquen
+// el mismo JWKS y sobrevivan a los reinicios; cada réplica guarda una copia en memoria que recarga
+// periódicamente y al recibir un kid desconocido.
+type KeyRing struct {
+	mu         sync.RWMutex
+	active     *ringKey
+	verifying  []*ringKey
+	repo       repositories.SigningKeyRepository
+	cipher     services.SecretCipher
+	generate   KeyGenerator
+	overlap    time.Duration
+	lastReload time.Time
+}
+
+// LoadKeyRing carga el key ring guardado. Si todavía no hay clave activa guardada, la obtiene de
+// bootstrap y la guarda; así la primera réplica en arrancar define la clave del resto. overlap es
+// el tiempo que una clave reemplazada sigue verificando tokens; debe ser al menos la vida de un access token.
+func LoadKeyRing(ctx context.Context, repo repositories.SigningKeyRepository, cipher services.SecretCipher, bootstrap, generate KeyGenerator, overlap time.Duration) (*KeyRing, error) {
+	r := &KeyRing{
+		repo:     repo,
+		cipher:   cipher,
+		generate: generate,
+		overlap:  overlap,
+	}
+	err := r.Reload(ctx)
+	if !errors.Is(err, errNoActiveKey) {
+		return r, err
+	}
+
+	key, err := bootstrap()
+	if err != nil {
+		return nil, err
+	}
+	record, err := r.newRecord(key, entities.SigningKeyStatusActive, time.Now(), nil)
+	if err != nil {
+		return nil, err
+	}
+	// Otra réplica pudo guardar su clave activa al mismo tiempo; en ese caso se usa la suya
+	if err := repo.Create(ctx, record); err != nil && !errors.Is(err, repositories.ErrSigningKeyConflict) {
+		return nil, err
+	}
+	log.Printf("No active signing key stored, using configured key %s", key.ID)
+	return r, r.Reload(ctx)
+}
+
+// AddVerificationKey guarda una clave previa que solo se usa para verificar hasta retiresAt.
+// Si ya estaba guardada se conserva su fecha de retiro original.
+func (r *KeyRing) AddVerificationKey(ctx context.Context, key *valueobjects.SigningKey, retiresAt time.Time) error {
+	record, err := r.newRecord(key, entities.SigningKeyStatusVerifying, time.Now(), &retiresAt)
+	if err != nil {
+		return err
+	}
+	if err := r.repo.Create(ctx, record); err != nil && !errors.Is(err, repositories.ErrSigningKeyConflict) {
+		return err
+	}
+	return r.Reload(ctx)
+}
+
+// Reload reemplaza la copia en memoria por las claves guardadas
+func (r *KeyRing) Reload(ctx context.Context) error {
+	records, err := r.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		active    *ringKey
+		verifying []*ringKey
+	)
+	for _, record := range records {
+		entry, err := r.decodeRecord(record)
+		if err != nil {
+			return fmt.Errorf("signing key %s: %w", record.ID, err)
+		}
+		if record.Status == entities.SigningKeyStatusActive {
+			active = entry
+		} else {
+			verifying = append(verifying, entry)
+		}
+	}
+	if active == nil {
+		return errNoActiveKey
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.active = active
+	r.verifying = verifying
+	r.lastReload = time.Now()
+	return nil
+}
+
+// Active devuelve la clave con la que se firman los tokens nuevos
+func (r *KeyRing) Active() *valueobjects.SigningKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.active.key
+}
+
+// ActiveSince devuelve el momento en que la clave activa fue promovida
+func (r *KeyRing) ActiveSince() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.active.createdAt
+}
+
+// VerificationKey busca por kid entre la clave activa y las claves de verificación no retiradas.
+// Un kid desconocido puede ser de una clave que otra réplica acaba de promover: se recarga el key ring
+// una vez, como mucho cada keyRingReloadInterval.
+func (r *KeyRing) VerificationKey(kid string) (*valueobjects.SigningKey, bool) {
+	if key, ok := r.findVerificationKey(kid); ok {
+		return key, true
+	}
+	if !r.claimReload() {
+		return nil, false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), keyRingReloadTimeout)
+	defer cancel()
+	if err := r.Reload(ctx); err != nil {
+		log.Println("Failed to reload signing keys:", err)
+		return nil, false
+	}
+	return r.findVerificationKey(kid)
+}
+
+// PublicKeys devuelve las claves vigentes que pueden publicarse en el JWKS
+func (r *KeyRing) PublicKeys() []*valueobjects.SigningKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]*valueobjects.SigningKey, 0, len(r.verifying)+1)
+	now := time.Now()
+	for _, entry := range append([]*ringKey{r.active}, r.verifying...) {
+		if entry.key.IsSymmetric() || (entry.retiresAt != nil && !now.Before(*entry.retiresAt)) {
+			continue
+		}
+		keys = append(keys, entry.key)
+	}
+	return keys
+}
+
+// Keys lista los metadatos de todas las claves del key ring
+func (r *KeyRing) Keys() []KeyInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	infos := []KeyInfo{newKeyInfo(r.active, KeyStatusActive)}
+	for _, entry := range r.verifying {
+		infos = append(infos, newKeyInfo(entry, KeyStatusVerifying))
+	}
+	return infos
+}
+
+// Rotate genera una clave nueva, la promueve a activa y deja la anterior solo para verificación.
+// Si otra réplica rotó antes, la clave generada se descarta sin guardarse: se recarga y se devuelve la suya.
+func (r *KeyRing) Rotate(ctx context.Context) (*valueobjects.SigningKey, error) {
+	next, err := r.generate()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	record, err := r.newRecord(next, entities.SigningKeyStatusActive, now, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = r.repo.Rotate(ctx, r.Active().ID, record, now.Add(r.overlap))
+	if err != nil && !errors.Is(err, repositories.ErrSigningKeyConflict) {
+		return nil, err
+	}
+	if err := r.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return r.Active(), nil
+}
+
+// Retire adelanta el retiro de una clave de verificación al momento actual
+func (r *KeyRing) Retire(ctx context.Context, kid string) error {
+	if r.Active().ID == kid {
+		return ErrActiveKeyRetirement
+	}
+	if err := r.repo.Retire(ctx, kid, time.Now()); err != nil {
+		if errors.Is(err, repositories.ErrSigningKeyNotFound) {
+			return ErrKeyNotFound
+		}
+		return err
+	}
+	return r.Reload(ctx)
+}
+
+// PruneRetired elimina de memoria las claves cuya fecha de retiro ya pasó y devuelve cuántas se
+// eliminaron; en la base las elimina el índice TTL
+func (r *KeyRing) PruneRetired() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	kept := r.verifying[:0]
+	for _, entry := range r.verifying {
+		if now.Before(*entry.retiresAt) {
+			kept = append(kept, entry)
+		}
+	}
+	pruned := len(r.verifying) - len(kept)
+	r.verifying = kept
+	return pruned
+}
+
+func (r *KeyRing) findVerificationKey(kid string) (*valueobjects.SigningKey, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.active.key.ID == kid {
+		return r.active.key, true
+	}
+	now := time.Now()
+	for _, entry := range r.verifying {
+		if entry.key.ID == kid && now.Before(*entry.retiresAt) {
+			return entry.key, true
+		}
+	}
+	return nil, false
+}
+
+// claimReload reserva la próxima recarga por kid desconocido si ya pasó keyRingReloadInterval
+func (r *KeyRing) claimReload() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.lastReload) < keyRingReloadInterval {
+		return false
+	}
+	r.lastReload = time.Now()
+	return true
+}
+
+func (r *KeyRing) newRecord(key *valueobjects.SigningKey, status string, createdAt time.Time, retiresAt *time.Time) (*entities.SigningKeyRecord, error) {
+	data, err := key.MarshalPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := r.cipher.Encrypt(string(data))
+	if err != nil {
+		return nil, err
+	}
+	return &entities.SigningKeyRecord{
+		ID:         key.ID,
+		Algorithm:  key.Algorithm,
+		PrivateKey: encrypted,
+		Status:     status,
+		CreatedAt:  createdAt,
+		RetiresAt:  retiresAt,
+	}, nil
+}
+
+func (r *KeyRing) decodeRecord(record *entities.SigningKeyRecord) (*ringKey, error) {
+	data, err := r.cipher.Decrypt(record.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := valueobjects.ParseSigningKey(record.ID, record.Algorithm, []byte(data))
+	if err != nil {
+		return nil, err
+	}
+	entry := &ringKey{key: key, createdAt: record.CreatedAt, retiresAt: record.RetiresAt}
+	if record.Status != entities.SigningKeyStatusActive && entry.retiresAt == nil {
+		return nil, errors.New("verification key without retirement date")
+	}
+	return entry, nil
+}
+
+func newKeyInfo(entry *ringKey, status KeyStatus) KeyInfo {
+	return KeyInfo{
+		ID:        entry.key.ID,
+		Algorithm: entry.key.Algorithm,
+		Status:    status,
+		CreatedAt: entry.createdAt,
+		RetiresAt: entry.retiresAt,
+	}
+}
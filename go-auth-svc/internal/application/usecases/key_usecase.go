@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"poc-auth-svc/internal/application/dtos"
+)
+
+type KeyUseCase interface {
+	ListKeys(ctx context.Context) []dtos.SigningKeyResponse
+	RotateKey(ctx context.Context) (*dtos.SigningKeyResponse, error)
+	RetireKey(ctx context.Context, kid string) error
+	// RunScheduledRotation recarga las claves guardadas, rota la clave activa cuando supera maxAge y purga
+	// las claves retiradas; bloquea hasta que ctx termine
+	RunScheduledRotation(ctx context.Context, interval, maxAge time.Duration)
+}
+
+type keyUseCase struct {
+	keyRing *KeyRing
+}
+
+func NewKeyUseCase(keyRing *KeyRing) KeyUseCase {
+	return &keyUseCase{
+		keyRing: keyRing,
+	}
+}
+
+// ListKeys implements KeyUseCase.
+func (uc *keyUseCase) ListKeys(ctx context.Context) []dtos.SigningKeyResponse {
+	keys := uc.keyRing.Keys()
+	response := make([]dtos.SigningKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, newSigningKeyResponse(key))
+	}
+	return response
+}
+
+// RotateKey implements KeyUseCase.
+func (uc *keyUseCase) RotateKey(ctx context.Context) (*dtos.SigningKeyResponse, error) {
+	key, err := uc.keyRing.Rotate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Signing key rotated, new active kid %s", key.ID)
+	for _, info := range uc.keyRing.Keys() {
+		if info.ID == key.ID {
+			response := newSigningKeyResponse(info)
+			return &response, nil
+		}
+	}
+	return nil, ErrKeyNotFound
+}
+
+// RetireKey implements KeyUseCase.
+func (uc *keyUseCase) RetireKey(ctx context.Context, kid string) error {
+	if err := uc.keyRing.Retire(ctx, kid); err != nil {
+		return err
+	}
+	uc.keyRing.PruneRetired()
+	return nil
+}
+
+// RunScheduledRotation implements KeyUseCase.
+func (uc *keyUseCase) RunScheduledRotation(ctx context.Context, interval, maxAge time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Recoge las rotaciones y retiros hechos por otras réplicas
+			if err := uc.keyRing.Reload(ctx); err != nil {
+				log.Println("Failed to reload signing keys:", err)
+			}
+			if maxAge > 0 && time.Since(uc.keyRing.ActiveSince()) >= maxAge {
+				if _, err := uc.RotateKey(ctx); err != nil {
+					log.Println("Scheduled signing key rotation failed:", err)
+				}
+			}
+			if pruned := uc.keyRing.PruneRetired(); pruned > 0 {
+				log.Printf("Pruned %d retired signing keys", pruned)
+			}
+		}
+	}
+}
+
+func newSigningKeyResponse(info KeyInfo) dtos.SigningKeyResponse {
+	return dtos.SigningKeyResponse{
+		KeyID:     info.ID,
+		Algorithm: info.Algorithm,
+		Status:    string(info.Status),
+		CreatedAt: info.CreatedAt,
+		RetiresAt: info.RetiresAt,
+	}
+}
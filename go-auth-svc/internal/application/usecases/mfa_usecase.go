@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/domain/services"
+)
+
+// QRCodeRenderer genera la imagen PNG de un código QR
+type QRCodeRenderer interface {
+	PNG(content string) ([]byte, error)
+}
+
+type MFAUseCase interface {
+	// Enroll inicia el registro TOTP; el segundo factor no se activa hasta Confirm
+	Enroll(ctx context.Context, userID string) (*dtos.MFAEnrollResponse, error)
+	// Confirm activa el segundo factor y devuelve los códigos de recuperación, que solo se muestran esta vez
+	Confirm(ctx context.Context, userID string, req *dtos.MFACodeRequest) (*dtos.MFARecoveryCodesResponse, error)
+	Disable(ctx context.Context, userID string, req *dtos.MFACodeRequest) error
+}
+
+type mfaUseCase struct {
+	mfaService services.MFAService
+	qrCode     QRCodeRenderer
+}
+
+func NewMFAUseCase(mfaService services.MFAService, qrCode QRCodeRenderer) MFAUseCase {
+	return &mfaUseCase{
+		mfaService: mfaService,
+		qrCode:     qrCode,
+	}
+}
+
+// Enroll implements MFAUseCase.
+func (uc *mfaUseCase) Enroll(ctx context.Context, userID string) (*dtos.MFAEnrollResponse, error) {
+	secret, uri, err := uc.mfaService.BeginEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	png, err := uc.qrCode.PNG(uri)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.MFAEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: uri,
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// Confirm implements MFAUseCase.
+func (uc *mfaUseCase) Confirm(ctx context.Context, userID string, req *dtos.MFACodeRequest) (*dtos.MFARecoveryCodesResponse, error) {
+	codes, err := uc.mfaService.ConfirmEnrollment(ctx, userID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable implements MFAUseCase.
+func (uc *mfaUseCase) Disable(ctx context.Context, userID string, req *dtos.MFACodeRequest) error {
+	return uc.mfaService.Disable(ctx, userID, req.Code)
+}
@@ -0,0 +1,439 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/services"
+	"poc-auth-svc/internal/domain/valueobjects"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeOpenID  = "openid"
+	ScopeEmail   = "email"
+	ScopeProfile = "profile"
+
+	tokenTypeHintRefreshToken = "refresh_token"
+)
+
+// Códigos de error de OAuth2 (RFC 6749 sección 4.1.2.1 y 5.2)
+const (
+	OAuthErrInvalidRequest          = "invalid_request"
+	OAuthErrInvalidClient           = "invalid_client"
+	OAuthErrInvalidGrant            = "invalid_grant"
+	OAuthErrUnauthorizedClient      = "unauthorized_client"
+	OAuthErrUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrInvalidScope            = "invalid_scope"
+	OAuthErrInvalidToken            = "invalid_token"
+	OAuthErrServerError             = "server_error"
+)
+
+// OAuthError error de protocolo que se devuelve al cliente con el formato de RFC 6749
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// OIDCConfig configuración del proveedor OpenID Connect
+type OIDCConfig struct {
+	// IssuerURL URL pública del servicio; se usa como "iss" y como base de los endpoints publicados
+	IssuerURL string
+}
+
+type OIDCUseCase interface {
+	Discovery(ctx context.Context) *dtos.DiscoveryResponse
+	// PrepareAuthorize valida la petición de autorización antes de mostrar el login. Si el error
+	// puede informarse al cliente devuelve la URL de redirección con el error en lugar de un error.
+	PrepareAuthorize(ctx context.Context, req *dtos.AuthorizeRequest) (string, error)
+	// Authorize autentica al usuario con las credenciales del formulario y devuelve la URL de redirección con el código
+	Authorize(ctx context.Context, req *dtos.AuthorizeRequest) (string, error)
+	Token(ctx context.Context, req *dtos.TokenRequest) (*dtos.TokenResponse, error)
+	UserInfo(ctx context.Context, tokenString string) (*dtos.UserInfoResponse, error)
+	// Introspect implementa RFC 7662 para access tokens y refresh tokens; solo clientes confidenciales pueden llamarlo
+	Introspect(ctx context.Context, req *dtos.IntrospectRequest) (*dtos.IntrospectionResponse, error)
+	RegisterClient(ctx context.Context, req *dtos.RegisterClientRequest) (*dtos.ClientResponse, error)
+	ListClients(ctx context.Context) ([]dtos.ClientResponse, error)
+}
+
+type oidcUseCase struct {
+	authService    services.AuthService
+	oauthService   services.OAuthService
+	refreshService services.RefreshTokenService
+	mfaService     services.MFAService
+	authUseCase    AuthUseCase
+	jwt            JwtWrapper
+	config         OIDCConfig
+}
+
+func NewOIDCUseCase(authService services.AuthService, oauthService services.OAuthService, refreshService services.RefreshTokenService, mfaService services.MFAService, authUseCase AuthUseCase, jwtWrapper JwtWrapper, config OIDCConfig) OIDCUseCase {
+	return &oidcUseCase{
+		authService:    authService,
+		oauthService:   oauthService,
+		refreshService: refreshService,
+		mfaService:     mfaService,
+		authUseCase:    authUseCase,
+		jwt:            jwtWrapper,
+		config:         config,
+	}
+}
+
+// Discovery implements OIDCUseCase.
+func (uc *oidcUseCase) Discovery(ctx context.Context) *dtos.DiscoveryResponse {
+	issuer := strings.TrimSuffix(uc.config.IssuerURL, "/")
+	return &dtos.DiscoveryResponse{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/oauth2/authorize",
+		TokenEndpoint:                     issuer + "/oauth2/token",
+		UserInfoEndpoint:                  issuer + "/userinfo",
+		IntrospectionEndpoint:             issuer + "/oauth2/introspect",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		ResponseTypesSupported:            []string{"code"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{uc.jwt.KeyRing.Active().Algorithm},
+		ScopesSupported:                   []string{ScopeOpenID, ScopeEmail, ScopeProfile},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		GrantTypesSupported:               []string{entities.GrantTypeAuthorizationCode, entities.GrantTypeClientCredentials},
+		CodeChallengeMethodsSupported:     []string{services.CodeChallengeMethodS256},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "email", "role"},
+	}
+}
+
+// PrepareAuthorize implements OIDCUseCase.
+func (uc *oidcUseCase) PrepareAuthorize(ctx context.Context, req *dtos.AuthorizeRequest) (string, error) {
+	if _, err := uc.getAuthorizeClient(ctx, req); err != nil {
+		return "", err
+	}
+	if oauthErr := validateAuthorizeParams(req); oauthErr != nil {
+		return authorizeRedirect(req, url.Values{"error": {oauthErr.Code}, "error_description": {oauthErr.Description}}), nil
+	}
+	return "", nil
+}
+
+// Authorize implements OIDCUseCase.
+func (uc *oidcUseCase) Authorize(ctx context.Context, req *dtos.AuthorizeRequest) (string, error) {
+	client, err := uc.getAuthorizeClient(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if oauthErr := validateAuthorizeParams(req); oauthErr != nil {
+		return authorizeRedirect(req, url.Values{"error": {oauthErr.Code}, "error_description": {oauthErr.Description}}), nil
+	}
+
+	// Un error de credenciales se devuelve tal cual para volver a mostrar el formulario
+	user, err := uc.authService.Login(ctx, req.Email, req.Password, req.ClientIP)
+	if err != nil {
+		return "", err
+	}
+	if user.MFAEnabled {
+		if err := uc.mfaService.VerifyLogin(ctx, user, req.OTP, "", req.ClientIP); err != nil {
+			return "", err
+		}
+	}
+	code, err := uc.oauthService.CreateAuthorizationCode(ctx, client, user.ID, req.RedirectURI, strings.Fields(req.Scope), req.Nonce, req.CodeChallenge, req.CodeChallengeMethod)
+	if err != nil {
+		oauthErr := toOAuthError(err)
+		return authorizeRedirect(req, url.Values{"error": {oauthErr.Code}, "error_description": {oauthErr.Description}}), nil
+	}
+	return authorizeRedirect(req, url.Values{"code": {code}}), nil
+}
+
+// Token implements OIDCUseCase.
+func (uc *oidcUseCase) Token(ctx context.Context, req *dtos.TokenRequest) (*dtos.TokenResponse, error) {
+	client, err := uc.oauthService.AuthenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, toOAuthError(err)
+	}
+	switch req.GrantType {
+	case entities.GrantTypeAuthorizationCode:
+		return uc.exchangeAuthorizationCode(ctx, client, req)
+	case entities.GrantTypeClientCredentials:
+		return uc.grantClientCredentials(ctx, client, req)
+	default:
+		return nil, &OAuthError{Code: OAuthErrUnsupportedGrantType}
+	}
+}
+
+// UserInfo implements OIDCUseCase.
+func (uc *oidcUseCase) UserInfo(ctx context.Context, tokenString string) (*dtos.UserInfoResponse, error) {
+	validation, err := uc.authUseCase.ValidateToken(ctx, tokenString)
+	if err != nil || !validation.Valid {
+		return nil, &OAuthError{Code: OAuthErrInvalidToken}
+	}
+	scope, _ := validation.Claims["scope"].(string)
+	scopes := strings.Fields(scope)
+	if !slices.Contains(scopes, ScopeOpenID) {
+		return nil, &OAuthError{Code: OAuthErrInvalidToken, Description: "token was not issued for the openid scope"}
+	}
+
+	response := &dtos.UserInfoResponse{Subject: validation.User.ID}
+	if slices.Contains(scopes, ScopeEmail) {
+		response.Email = validation.User.Email
+	}
+	if slices.Contains(scopes, ScopeProfile) {
+		response.Role = validation.User.Role
+	}
+	return response, nil
+}
+
+// Introspect implements OIDCUseCase.
+func (uc *oidcUseCase) Introspect(ctx context.Context, req *dtos.IntrospectRequest) (*dtos.IntrospectionResponse, error) {
+	client, err := uc.oauthService.AuthenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil || client.Public {
+		return nil, &OAuthError{Code: OAuthErrInvalidClient}
+	}
+	if req.Token == "" {
+		return nil, &OAuthError{Code: OAuthErrInvalidRequest, Description: "token is required"}
+	}
+
+	// token_type_hint solo decide el orden de búsqueda (RFC 7662 sección 2.1)
+	introspectors := []func(context.Context, string) *dtos.IntrospectionResponse{uc.introspectAccessToken, uc.introspectRefreshToken}
+	if req.TokenTypeHint == tokenTypeHintRefreshToken {
+		introspectors[0], introspectors[1] = introspectors[1], introspectors[0]
+	}
+	for _, introspect := range introspectors {
+		if response := introspect(ctx, req.Token); response != nil {
+			return response, nil
+		}
+	}
+	return &dtos.IntrospectionResponse{Active: false}, nil
+}
+
+func (uc *oidcUseCase) introspectAccessToken(ctx context.Context, token string) *dtos.IntrospectionResponse {
+	validation, err := uc.authUseCase.ValidateToken(ctx, token)
+	if err != nil || !validation.Valid {
+		return nil
+	}
+	claims := validation.Claims
+	response := &dtos.IntrospectionResponse{
+		Active:    true,
+		TokenType: "Bearer",
+	}
+	response.Scope, _ = claims["scope"].(string)
+	response.ClientID, _ = claims["client_id"].(string)
+	response.Audience, _ = claims["aud"].(string)
+	response.Issuer, _ = claims["iss"].(string)
+	response.JTI, _ = claims["jti"].(string)
+	response.Exp, _ = claims["exp"].(int64)
+	response.Iat, _ = claims["iat"].(int64)
+	if validation.User != nil {
+		response.Subject = validation.User.ID
+		response.Username = validation.User.Email
+		response.Roles = validation.User.Roles
+		response.Permissions = validation.User.Permissions
+	} else {
+		response.Subject, _ = claims["sub"].(string)
+	}
+	return response
+}
+
+func (uc *oidcUseCase) introspectRefreshToken(ctx context.Context, token string) *dtos.IntrospectionResponse {
+	refreshToken, err := uc.refreshService.Lookup(ctx, token)
+	if err != nil {
+		return nil
+	}
+	user, err := uc.authService.GetUserByID(ctx, refreshToken.UserID)
+	if err != nil || !user.IsActive {
+		return nil
+	}
+	return &dtos.IntrospectionResponse{
+		Active:    true,
+		TokenType: tokenTypeHintRefreshToken,
+		Subject:   user.ID,
+		Username:  user.Email,
+		Exp:       refreshToken.ExpiresAt.Unix(),
+		Iat:       refreshToken.CreatedAt.Unix(),
+		Issuer:    uc.jwt.Issuer,
+	}
+}
+
+// RegisterClient implements OIDCUseCase.
+func (uc *oidcUseCase) RegisterClient(ctx context.Context, req *dtos.RegisterClientRequest) (*dtos.ClientResponse, error) {
+	scopes := req.Scopes
+	if len(scopes) == 0 && (len(req.GrantTypes) == 0 || slices.Contains(req.GrantTypes, entities.GrantTypeAuthorizationCode)) {
+		scopes = []string{ScopeOpenID, ScopeEmail, ScopeProfile}
+	}
+	client, secret, err := uc.oauthService.RegisterClient(ctx, req.Name, req.RedirectURIs, req.GrantTypes, scopes, req.Public)
+	if err != nil {
+		return nil, err
+	}
+	response := newClientResponse(client)
+	// El secreto solo se muestra una vez, al registrar
+	response.ClientSecret = secret
+	return &response, nil
+}
+
+// ListClients implements OIDCUseCase.
+func (uc *oidcUseCase) ListClients(ctx context.Context) ([]dtos.ClientResponse, error) {
+	clients, err := uc.oauthService.ListClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dtos.ClientResponse, 0, len(clients))
+	for _, client := range clients {
+		response = append(response, newClientResponse(client))
+	}
+	return response, nil
+}
+
+func (uc *oidcUseCase) exchangeAuthorizationCode(ctx context.Context, client *entities.Client, req *dtos.TokenRequest) (*dtos.TokenResponse, error) {
+	code, err := uc.oauthService.ExchangeAuthorizationCode(ctx, client, req.Code, req.RedirectURI, req.CodeVerifier)
+	if err != nil {
+		return nil, toOAuthError(err)
+	}
+	user, err := uc.authService.GetUserByID(ctx, code.UserID)
+	if err != nil || !user.IsActive {
+		return nil, &OAuthError{Code: OAuthErrInvalidGrant, Description: err_domain.GetMessage(err_domain.UserInactive)}
+	}
+
+	scope := strings.Join(code.Scopes, " ")
+	accessClaims, err := uc.jwt.newAccessClaims(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	accessClaims.Scope = scope
+	accessClaims.Audience = client.ID
+	accessClaims.ClientID = client.ID
+	accessToken, err := uc.jwt.sign(accessClaims)
+	if err != nil {
+		return nil, err
+	}
+	idToken, err := uc.generateIDToken(user, client, code)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(uc.jwt.accessTokenTTL().Seconds()),
+		IDToken:     idToken,
+		Scope:       scope,
+	}, nil
+}
+
+// grantClientCredentials emite un access token propio del service account, sin refresh token (RFC 6749 sección 4.4.3)
+func (uc *oidcUseCase) grantClientCredentials(ctx context.Context, client *entities.Client, req *dtos.TokenRequest) (*dtos.TokenResponse, error) {
+	scopes, err := uc.oauthService.GrantClientCredentials(ctx, client, strings.Fields(req.Scope))
+	if err != nil {
+		return nil, toOAuthError(err)
+	}
+	scope := strings.Join(scopes, " ")
+	accessToken, err := uc.jwt.sign(uc.jwt.newClientClaims(client, scope))
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(uc.jwt.accessTokenTTL().Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+func (uc *oidcUseCase) generateIDToken(user *entities.User, client *entities.Client, code *entities.AuthorizationCode) (string, error) {
+	now := time.Now()
+	claims := &valueobjects.IDTokenClaims{
+		Nonce:    code.Nonce,
+		AuthTime: code.AuthTime.Unix(),
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			Subject:   user.ID,
+			Audience:  client.ID,
+			Issuer:    strings.TrimSuffix(uc.config.IssuerURL, "/"),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(uc.jwt.accessTokenTTL()).Unix(),
+		},
+	}
+	if slices.Contains(code.Scopes, ScopeEmail) {
+		claims.Email = user.Email
+	}
+	return uc.jwt.signWithType(claims, tokenTypeIDToken)
+}
+
+// getAuthorizeClient valida client_id y redirect_uri; sus errores nunca se redirigen al cliente
+func (uc *oidcUseCase) getAuthorizeClient(ctx context.Context, req *dtos.AuthorizeRequest) (*entities.Client, error) {
+	client, err := uc.oauthService.GetClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, toOAuthError(err)
+	}
+	if !client.HasRedirectURI(req.RedirectURI) {
+		return nil, toOAuthError(services.ErrInvalidRedirectURI)
+	}
+	return client, nil
+}
+
+func validateAuthorizeParams(req *dtos.AuthorizeRequest) *OAuthError {
+	if req.ResponseType != "code" {
+		return &OAuthError{Code: OAuthErrUnsupportedResponseType}
+	}
+	if !slices.Contains(strings.Fields(req.Scope), ScopeOpenID) {
+		return &OAuthError{Code: OAuthErrInvalidScope, Description: "the openid scope is required"}
+	}
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != services.CodeChallengeMethodS256 {
+		return &OAuthError{Code: OAuthErrInvalidRequest, Description: "PKCE with code_challenge_method S256 is required"}
+	}
+	return nil
+}
+
+// authorizeRedirect agrega los parámetros de respuesta y el state a la redirect_uri del cliente
+func authorizeRedirect(req *dtos.AuthorizeRequest, params url.Values) string {
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	separator := "?"
+	if strings.Contains(req.RedirectURI, "?") {
+		separator = "&"
+	}
+	return req.RedirectURI + separator + params.Encode()
+}
+
+// toOAuthError traduce los errores del dominio a códigos de error de OAuth2
+func toOAuthError(err error) *OAuthError {
+	var oauthErr *OAuthError
+	switch {
+	case errors.As(err, &oauthErr):
+		return oauthErr
+	case errors.Is(err, services.ErrInvalidClient):
+		return &OAuthError{Code: OAuthErrInvalidClient, Description: err.Error()}
+	case errors.Is(err, services.ErrInvalidRedirectURI):
+		return &OAuthError{Code: OAuthErrInvalidRequest, Description: err.Error()}
+	case errors.Is(err, services.ErrInvalidScope):
+		return &OAuthError{Code: OAuthErrInvalidScope, Description: err.Error()}
+	case errors.Is(err, services.ErrUnauthorizedGrant):
+		return &OAuthError{Code: OAuthErrUnauthorizedClient, Description: err.Error()}
+	case errors.Is(err, services.ErrInvalidAuthorizationCode), errors.Is(err, services.ErrInvalidCodeVerifier):
+		return &OAuthError{Code: OAuthErrInvalidGrant, Description: err.Error()}
+	default:
+		return &OAuthError{Code: OAuthErrServerError}
+	}
+}
+
+func newClientResponse(client *entities.Client) dtos.ClientResponse {
+	return dtos.ClientResponse{
+		ClientID:     client.ID,
+		Name:         client.Name,
+		RedirectURIs: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Scopes:       client.Scopes,
+		Public:       client.Public,
+		IsActive:     client.IsActive,
+		CreatedAt:    client.CreatedAt,
+	}
+}
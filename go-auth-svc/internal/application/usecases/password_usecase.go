@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/domain/services"
+)
+
+// passwordResetRequestTimeout límite para emitir y entregar el token fuera del ciclo de la petición
+const passwordResetRequestTimeout = 30 * time.Second
+
+type PasswordUseCase interface {
+	// ForgotPassword procesa la solicitud en segundo plano para que el tiempo de respuesta no revele si el email existe
+	ForgotPassword(ctx context.Context, req *dtos.ForgotPasswordRequest)
+	// ResetPassword cambia la contraseña con el token recibido y cierra todas las sesiones del usuario
+	ResetPassword(ctx context.Context, req *dtos.ResetPasswordRequest) error
+	// ChangePassword cambia la contraseña del usuario autenticado. Si se cierran las demás sesiones,
+	// se revocan todos los tokens y se devuelve una sesión nueva para el cliente actual; si no, la respuesta es nil.
+	ChangePassword(ctx context.Context, userID string, req *dtos.ChangePasswordRequest) (*dtos.AuthResponse, error)
+}
+
+type passwordUseCase struct {
+	passwordService   services.PasswordService
+	revocationService services.TokenRevocationService
+	refreshService    services.RefreshTokenService
+	jwt               JwtWrapper
+}
+
+func NewPasswordUseCase(passwordService services.PasswordService, revocationService services.TokenRevocationService, refreshService services.RefreshTokenService, jwtWrapper JwtWrapper) PasswordUseCase {
+	return &passwordUseCase{
+		passwordService:   passwordService,
+		revocationService: revocationService,
+		refreshService:    refreshService,
+		jwt:               jwtWrapper,
+	}
+}
+
+// ForgotPassword implements PasswordUseCase.
+func (uc *passwordUseCase) ForgotPassword(ctx context.Context, req *dtos.ForgotPasswordRequest) {
+	email := req.Email
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), passwordResetRequestTimeout)
+		defer cancel()
+		if err := uc.passwordService.RequestReset(ctx, email); err != nil {
+			log.Printf("Password reset request failed: %v", err)
+		}
+	}()
+}
+
+// ResetPassword implements PasswordUseCase.
+func (uc *passwordUseCase) ResetPassword(ctx context.Context, req *dtos.ResetPasswordRequest) error {
+	user, err := uc.passwordService.ResetPassword(ctx, req.Token, req.Password)
+	if err != nil {
+		return err
+	}
+	return uc.revocationService.RevokeAllForUser(ctx, user.ID)
+}
+
+// ChangePassword implements PasswordUseCase.
+func (uc *passwordUseCase) ChangePassword(ctx context.Context, userID string, req *dtos.ChangePasswordRequest) (*dtos.AuthResponse, error) {
+	user, err := uc.passwordService.ChangePassword(ctx, userID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+	if !req.LogoutOtherSessions {
+		return nil, nil
+	}
+	if err := uc.revocationService.RevokeAllForUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return issueSession(ctx, uc.refreshService, uc.jwt, user)
+}
@@ -0,0 +1,115 @@
+package usecases
+
+import (
+	"context"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+	"poc-auth-svc/internal/domain/services"
+)
+
+// RoleUseCase administración de roles. actorID es el administrador que ejecuta la acción: solo puede
+// crear, modificar o eliminar roles cuyos permisos tiene.
+type RoleUseCase interface {
+	ListRoles(ctx context.Context) ([]dtos.RoleResponse, error)
+	CreateRole(ctx context.Context, actorID string, req *dtos.CreateRoleRequest) (*dtos.RoleResponse, error)
+	UpdateRole(ctx context.Context, actorID, name string, req *dtos.UpdateRoleRequest) (*dtos.RoleResponse, error)
+	DeleteRole(ctx context.Context, actorID, name string) error
+	ListPermissions(ctx context.Context) ([]dtos.PermissionResponse, error)
+}
+
+type roleUseCase struct {
+	authorization services.AuthorizationService
+	userRepo      repositories.UserRepository
+}
+
+func NewRoleUseCase(authorization services.AuthorizationService, userRepo repositories.UserRepository) RoleUseCase {
+	return &roleUseCase{
+		authorization: authorization,
+		userRepo:      userRepo,
+	}
+}
+
+// ListRoles implements RoleUseCase.
+func (uc *roleUseCase) ListRoles(ctx context.Context) ([]dtos.RoleResponse, error) {
+	roles, err := uc.authorization.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dtos.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		response = append(response, newRoleResponse(role))
+	}
+	return response, nil
+}
+
+// CreateRole implements RoleUseCase.
+func (uc *roleUseCase) CreateRole(ctx context.Context, actorID string, req *dtos.CreateRoleRequest) (*dtos.RoleResponse, error) {
+	actor, err := uc.actor(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := uc.authorization.CreateRole(ctx, actor, req.Name, req.Description, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	response := newRoleResponse(role)
+	return &response, nil
+}
+
+// UpdateRole implements RoleUseCase.
+func (uc *roleUseCase) UpdateRole(ctx context.Context, actorID, name string, req *dtos.UpdateRoleRequest) (*dtos.RoleResponse, error) {
+	actor, err := uc.actor(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := uc.authorization.UpdateRole(ctx, actor, name, req.Description, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	response := newRoleResponse(role)
+	return &response, nil
+}
+
+// DeleteRole implements RoleUseCase.
+func (uc *roleUseCase) DeleteRole(ctx context.Context, actorID, name string) error {
+	actor, err := uc.actor(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	return uc.authorization.DeleteRole(ctx, actor, name)
+}
+
+// ListPermissions implements RoleUseCase.
+func (uc *roleUseCase) ListPermissions(ctx context.Context) ([]dtos.PermissionResponse, error) {
+	permissions, err := uc.authorization.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dtos.PermissionResponse, 0, len(permissions))
+	for _, permission := range permissions {
+		response = append(response, dtos.PermissionResponse{Name: permission.Name, Description: permission.Description})
+	}
+	return response, nil
+}
+
+// actor carga al administrador que ejecuta la acción; uno inexistente o inactivo no tiene permisos
+func (uc *roleUseCase) actor(ctx context.Context, actorID string) (*entities.User, error) {
+	actor, err := uc.userRepo.GetByID(ctx, actorID)
+	if err != nil || !actor.IsActive {
+		return nil, services.ErrPermissionDenied
+	}
+	return actor, nil
+}
+
+func newRoleResponse(role *entities.Role) dtos.RoleResponse {
+	return dtos.RoleResponse{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		System:      role.System,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
@@ -0,0 +1,190 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+	"poc-auth-svc/internal/domain/services"
+)
+
+const (
+	defaultUserListLimit = 20
+	maxUserListLimit     = 100
+)
+
+// UserAdminUseCase administración de usuarios. actorID es el administrador que ejecuta la acción:
+// no puede cambiar sus propios roles, desactivarse ni eliminarse para no perder el acceso por error, ni
+// actuar sobre usuarios con permisos que él no tiene.
+type UserAdminUseCase interface {
+	// ListUsers devuelve un *time.ParseError si alguna fecha del filtro no está en RFC 3339
+	// y repositories.ErrInvalidCursor si el cursor no corresponde al orden pedido
+	ListUsers(ctx context.Context, req *dtos.ListUsersRequest) (*dtos.UserListResponse, error)
+	GetUser(ctx context.Context, userID string) (*dtos.AdminUserResponse, error)
+	UpdateRoles(ctx context.Context, actorID, userID string, req *dtos.UpdateUserRolesRequest) (*dtos.AdminUserResponse, error)
+	Activate(ctx context.Context, actorID, userID string) (*dtos.AdminUserResponse, error)
+	// Deactivate además revoca todas las sesiones del usuario
+	Deactivate(ctx context.Context, actorID, userID string) (*dtos.AdminUserResponse, error)
+	// ForcePasswordReset bloquea el login con contraseña, revoca las sesiones y envía un enlace de recuperación
+	ForcePasswordReset(ctx context.Context, actorID, userID string) error
+	DeleteUser(ctx context.Context, actorID, userID string) error
+}
+
+type userAdminUseCase struct {
+	userAdminService  services.UserAdminService
+	passwordService   services.PasswordService
+	revocationService services.TokenRevocationService
+}
+
+func NewUserAdminUseCase(userAdminService services.UserAdminService, passwordService services.PasswordService, revocationService services.TokenRevocationService) UserAdminUseCase {
+	return &userAdminUseCase{
+		userAdminService:  userAdminService,
+		passwordService:   passwordService,
+		revocationService: revocationService,
+	}
+}
+
+// ListUsers implements UserAdminUseCase.
+func (uc *userAdminUseCase) ListUsers(ctx context.Context, req *dtos.ListUsersRequest) (*dtos.UserListResponse, error) {
+	filter := repositories.UserFilter{
+		EmailPrefix:   req.EmailPrefix,
+		Role:          req.Role,
+		IsActive:      req.Active,
+		EmailVerified: req.EmailVerified,
+	}
+	for _, bound := range []struct {
+		value  string
+		target **time.Time
+	}{
+		{req.CreatedFrom, &filter.CreatedFrom},
+		{req.CreatedTo, &filter.CreatedTo},
+		{req.UpdatedFrom, &filter.UpdatedFrom},
+		{req.UpdatedTo, &filter.UpdatedTo},
+	} {
+		parsed, err := parseOptionalTime(bound.value)
+		if err != nil {
+			return nil, err
+		}
+		*bound.target = parsed
+	}
+	page := repositories.Page{
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+		SortBy: repositories.UserSortField(req.Sort),
+		// Sin orden explícito los más recientes primero, como espera la vista de administración
+		Descending: req.Order == "desc" || (req.Order == "" && req.Sort == ""),
+	}
+	if page.Limit <= 0 {
+		page.Limit = defaultUserListLimit
+	}
+	page.Limit = min(page.Limit, maxUserListLimit)
+
+	result, err := uc.userAdminService.List(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+	response := &dtos.UserListResponse{
+		Users:      make([]dtos.AdminUserResponse, 0, len(result.Users)),
+		Total:      result.Total,
+		NextCursor: result.NextCursor,
+		Limit:      page.Limit,
+	}
+	for _, user := range result.Users {
+		response.Users = append(response.Users, *newAdminUserResponse(user))
+	}
+	return response, nil
+}
+
+// GetUser implements UserAdminUseCase.
+func (uc *userAdminUseCase) GetUser(ctx context.Context, userID string) (*dtos.AdminUserResponse, error) {
+	user, err := uc.userAdminService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return newAdminUserResponse(user), nil
+}
+
+// UpdateRoles implements UserAdminUseCase.
+func (uc *userAdminUseCase) UpdateRoles(ctx context.Context, actorID, userID string, req *dtos.UpdateUserRolesRequest) (*dtos.AdminUserResponse, error) {
+	if actorID == userID {
+		return nil, services.ErrSelfModification
+	}
+	user, err := uc.userAdminService.SetRoles(ctx, actorID, userID, req.Roles)
+	if err != nil {
+		return nil, err
+	}
+	return newAdminUserResponse(user), nil
+}
+
+// Activate implements UserAdminUseCase.
+func (uc *userAdminUseCase) Activate(ctx context.Context, actorID, userID string) (*dtos.AdminUserResponse, error) {
+	if actorID == userID {
+		return nil, services.ErrSelfModification
+	}
+	user, err := uc.userAdminService.SetActive(ctx, actorID, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	return newAdminUserResponse(user), nil
+}
+
+// Deactivate implements UserAdminUseCase.
+func (uc *userAdminUseCase) Deactivate(ctx context.Context, actorID, userID string) (*dtos.AdminUserResponse, error) {
+	if actorID == userID {
+		return nil, services.ErrSelfModification
+	}
+	user, err := uc.userAdminService.SetActive(ctx, actorID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.revocationService.RevokeAllForUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return newAdminUserResponse(user), nil
+}
+
+// ForcePasswordReset implements UserAdminUseCase.
+func (uc *userAdminUseCase) ForcePasswordReset(ctx context.Context, actorID, userID string) error {
+	// Se comprueba primero para responder 404 en lugar del error genérico del repositorio, y que actorID
+	// tenga al menos los permisos del usuario
+	if _, err := uc.userAdminService.Authorize(ctx, actorID, userID); err != nil {
+		return err
+	}
+	if err := uc.passwordService.ForceReset(ctx, userID); err != nil {
+		return err
+	}
+	return uc.revocationService.RevokeAllForUser(ctx, userID)
+}
+
+// DeleteUser implements UserAdminUseCase.
+func (uc *userAdminUseCase) DeleteUser(ctx context.Context, actorID, userID string) error {
+	if actorID == userID {
+		return services.ErrSelfModification
+	}
+	if err := uc.userAdminService.Delete(ctx, actorID, userID); err != nil {
+		return err
+	}
+	return uc.revocationService.RevokeAllForUser(ctx, userID)
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func newAdminUserResponse(user *entities.User) *dtos.AdminUserResponse {
+	return &dtos.AdminUserResponse{
+		UserResponse:          *newUserResponse(user),
+		PasswordResetRequired: user.PasswordResetRequired,
+		CreatedAt:             user.CreatedAt,
+		UpdatedAt:             user.UpdatedAt,
+	}
+}
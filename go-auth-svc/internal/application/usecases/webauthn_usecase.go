@@ -0,0 +1,199 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/services"
+)
+
+const (
+	publicKeyCredentialType          = "public-key"
+	webAuthnUserVerificationRequired = "required"
+)
+
+// WebAuthnConfig datos del relying party que se envían al navegador en las opciones de cada ceremonia
+type WebAuthnConfig struct {
+	RPID   string
+	RPName string
+	// Algorithms algoritmos COSE aceptados, en orden de preferencia
+	Algorithms []int64
+	Timeout    time.Duration
+}
+
+type WebAuthnUseCase interface {
+	BeginRegistration(ctx context.Context, userID string) (*dtos.PublicKeyCredentialCreationOptions, error)
+	FinishRegistration(ctx context.Context, userID string, req *dtos.WebAuthnRegisterFinishRequest) (*dtos.WebAuthnCredentialResponse, error)
+	BeginLogin(ctx context.Context, req *dtos.WebAuthnLoginBeginRequest) (*dtos.PublicKeyCredentialRequestOptions, error)
+	// FinishLogin verifica la aserción y emite los mismos tokens que el login con contraseña
+	FinishLogin(ctx context.Context, req *dtos.WebAuthnLoginFinishRequest) (*dtos.AuthResponse, error)
+	ListCredentials(ctx context.Context, userID string) ([]dtos.WebAuthnCredentialResponse, error)
+	DeleteCredential(ctx context.Context, userID, id string) error
+}
+
+type webAuthnUseCase struct {
+	webAuthnService services.WebAuthnService
+	authService     services.AuthService
+	refreshService  services.RefreshTokenService
+	jwt             JwtWrapper
+	config          WebAuthnConfig
+}
+
+func NewWebAuthnUseCase(webAuthnService services.WebAuthnService, authService services.AuthService, refreshService services.RefreshTokenService, jwtWrapper JwtWrapper, config WebAuthnConfig) WebAuthnUseCase {
+	return &webAuthnUseCase{
+		webAuthnService: webAuthnService,
+		authService:     authService,
+		refreshService:  refreshService,
+		jwt:             jwtWrapper,
+		config:          config,
+	}
+}
+
+// BeginRegistration implements WebAuthnUseCase.
+func (uc *webAuthnUseCase) BeginRegistration(ctx context.Context, userID string) (*dtos.PublicKeyCredentialCreationOptions, error) {
+	user, err := uc.authService.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	session, credentials, err := uc.webAuthnService.BeginRegistration(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]dtos.PublicKeyCredentialParameters, 0, len(uc.config.Algorithms))
+	for _, algorithm := range uc.config.Algorithms {
+		params = append(params, dtos.PublicKeyCredentialParameters{Type: publicKeyCredentialType, Algorithm: algorithm})
+	}
+	return &dtos.PublicKeyCredentialCreationOptions{
+		Challenge:    session.Challenge,
+		RelyingParty: dtos.RelyingPartyEntity{ID: uc.config.RPID, Name: uc.config.RPName},
+		// El user handle es el ID del usuario; el autenticador lo devuelve en userHandle al autenticar
+		User: dtos.WebAuthnUserEntity{
+			ID:          base64.RawURLEncoding.EncodeToString([]byte(user.ID)),
+			Name:        user.Email,
+			DisplayName: user.Email,
+		},
+		PubKeyCredParams:   params,
+		Timeout:            uc.config.Timeout.Milliseconds(),
+		ExcludeCredentials: newCredentialDescriptors(credentials),
+		AuthenticatorSelection: dtos.AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: webAuthnUserVerificationRequired,
+		},
+		Attestation: "direct",
+	}, nil
+}
+
+// FinishRegistration implements WebAuthnUseCase.
+func (uc *webAuthnUseCase) FinishRegistration(ctx context.Context, userID string, req *dtos.WebAuthnRegisterFinishRequest) (*dtos.WebAuthnCredentialResponse, error) {
+	clientDataJSON, err := decodeBase64URL(req.Response.ClientDataJSON)
+	if err != nil {
+		return nil, services.ErrWebAuthnVerificationFailed
+	}
+	attestationObject, err := decodeBase64URL(req.Response.AttestationObject)
+	if err != nil {
+		return nil, services.ErrWebAuthnVerificationFailed
+	}
+	credential, err := uc.webAuthnService.FinishRegistration(ctx, userID, req.Name, clientDataJSON, attestationObject, req.Response.Transports)
+	if err != nil {
+		return nil, err
+	}
+	response := newWebAuthnCredentialResponse(credential)
+	return &response, nil
+}
+
+// BeginLogin implements WebAuthnUseCase.
+func (uc *webAuthnUseCase) BeginLogin(ctx context.Context, req *dtos.WebAuthnLoginBeginRequest) (*dtos.PublicKeyCredentialRequestOptions, error) {
+	session, credentials, err := uc.webAuthnService.BeginLogin(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.PublicKeyCredentialRequestOptions{
+		Challenge:        session.Challenge,
+		RelyingPartyID:   uc.config.RPID,
+		Timeout:          uc.config.Timeout.Milliseconds(),
+		AllowCredentials: newCredentialDescriptors(credentials),
+		UserVerification: webAuthnUserVerificationRequired,
+	}, nil
+}
+
+// FinishLogin implements WebAuthnUseCase.
+func (uc *webAuthnUseCase) FinishLogin(ctx context.Context, req *dtos.WebAuthnLoginFinishRequest) (*dtos.AuthResponse, error) {
+	clientDataJSON, err := decodeBase64URL(req.Response.ClientDataJSON)
+	if err != nil {
+		return nil, services.ErrWebAuthnVerificationFailed
+	}
+	authenticatorData, err := decodeBase64URL(req.Response.AuthenticatorData)
+	if err != nil {
+		return nil, services.ErrWebAuthnVerificationFailed
+	}
+	signature, err := decodeBase64URL(req.Response.Signature)
+	if err != nil {
+		return nil, services.ErrWebAuthnVerificationFailed
+	}
+	userHandle, err := decodeBase64URL(req.Response.UserHandle)
+	if err != nil {
+		return nil, services.ErrWebAuthnVerificationFailed
+	}
+
+	user, err := uc.webAuthnService.FinishLogin(ctx, strings.TrimRight(req.ID, "="), clientDataJSON, authenticatorData, signature, userHandle)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authService.CheckLoginPolicy(user); err != nil {
+		return nil, err
+	}
+	return issueSession(ctx, uc.refreshService, uc.jwt, user)
+}
+
+// ListCredentials implements WebAuthnUseCase.
+func (uc *webAuthnUseCase) ListCredentials(ctx context.Context, userID string) ([]dtos.WebAuthnCredentialResponse, error) {
+	credentials, err := uc.webAuthnService.ListCredentials(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dtos.WebAuthnCredentialResponse, 0, len(credentials))
+	for _, credential := range credentials {
+		response = append(response, newWebAuthnCredentialResponse(credential))
+	}
+	return response, nil
+}
+
+// DeleteCredential implements WebAuthnUseCase.
+func (uc *webAuthnUseCase) DeleteCredential(ctx context.Context, userID, id string) error {
+	return uc.webAuthnService.DeleteCredential(ctx, userID, id)
+}
+
+func newCredentialDescriptors(credentials []*entities.WebAuthnCredential) []dtos.PublicKeyCredentialDescriptor {
+	descriptors := make([]dtos.PublicKeyCredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		descriptors = append(descriptors, dtos.PublicKeyCredentialDescriptor{
+			Type:       publicKeyCredentialType,
+			ID:         credential.CredentialID,
+			Transports: credential.Transports,
+		})
+	}
+	return descriptors
+}
+
+func newWebAuthnCredentialResponse(credential *entities.WebAuthnCredential) dtos.WebAuthnCredentialResponse {
+	return dtos.WebAuthnCredentialResponse{
+		ID:                credential.ID,
+		CredentialID:      credential.CredentialID,
+		Name:              credential.Name,
+		AAGUID:            credential.AAGUID,
+		AttestationFormat: credential.AttestationFormat,
+		SignCount:         credential.SignCount,
+		CreatedAt:         credential.CreatedAt,
+		LastUsedAt:        credential.LastUsedAt,
+	}
+}
+
+// decodeBase64URL acepta base64url con o sin padding, como lo serializan los distintos navegadores
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
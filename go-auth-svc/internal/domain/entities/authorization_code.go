@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthorizationCode código de un solo uso emitido en /oauth2/authorize y canjeado en /oauth2/token
+type AuthorizationCode struct {
+	ID                  string     `json:"id" bson:"_id,omitempty"`
+	CodeHash            string     `json:"-" bson:"code_hash"`
+	ClientID            string     `json:"client_id" bson:"client_id"`
+	UserID              string     `json:"user_id" bson:"user_id"`
+	RedirectURI         string     `json:"redirect_uri" bson:"redirect_uri"`
+	Scopes              []string   `json:"scopes" bson:"scopes"`
+	Nonce               string     `json:"nonce,omitempty" bson:"nonce,omitempty"`
+	CodeChallenge       string     `json:"-" bson:"code_challenge"`
+	CodeChallengeMethod string     `json:"-" bson:"code_challenge_method"`
+	AuthTime            time.Time  `json:"auth_time" bson:"auth_time"`
+	ExpiresAt           time.Time  `json:"expires_at" bson:"expires_at"`
+	UsedAt              *time.Time `json:"used_at,omitempty" bson:"used_at,omitempty"`
+}
+
+func NewAuthorizationCode(codeHash, clientID, userID, redirectURI string, scopes []string, nonce, codeChallenge, codeChallengeMethod string, ttl time.Duration) *AuthorizationCode {
+	now := time.Now()
+	return &AuthorizationCode{
+		ID:                  uuid.New().String(),
+		CodeHash:            codeHash,
+		ClientID:            clientID,
+		UserID:              userID,
+		RedirectURI:         redirectURI,
+		Scopes:              scopes,
+		Nonce:               nonce,
+		CodeChallenge:       codeChallenge,
+		CodeChallengeMethod: codeChallengeMethod,
+		AuthTime:            now,
+		ExpiresAt:           now.Add(ttl),
+	}
+}
+
+func (a *AuthorizationCode) IsExpired() bool {
+	return time.Now().After(a.ExpiresAt)
+}
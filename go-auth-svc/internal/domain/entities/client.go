@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeClientCredentials = "client_credentials"
+)
+
+// Client aplicación registrada que puede solicitar tokens por OAuth2/OIDC.
+// Los clientes públicos (SPA, móviles) no tienen secreto y deben usar PKCE.
+// Los service accounts son clientes confidenciales con el grant client_credentials.
+type Client struct {
+	ID           string    `json:"client_id" bson:"_id,omitempty"`
+	SecretHash   string    `json:"-" bson:"secret_hash,omitempty"`
+	Name         string    `json:"name" bson:"name"`
+	RedirectURIs []string  `json:"redirect_uris" bson:"redirect_uris"`
+	GrantTypes   []string  `json:"grant_types" bson:"grant_types"`
+	Scopes       []string  `json:"scopes" bson:"scopes"`
+	Public       bool      `json:"public" bson:"public"`
+	IsActive     bool      `json:"is_active" bson:"is_active"`
+	CreatedAt    time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+func NewClient(name string, redirectURIs, grantTypes, scopes []string, public bool) (*Client, error) {
+	if name == "" {
+		return nil, errors.New("client name is required")
+	}
+	if len(grantTypes) == 0 {
+		grantTypes = []string{GrantTypeAuthorizationCode}
+	}
+	if slices.Contains(grantTypes, GrantTypeAuthorizationCode) && len(redirectURIs) == 0 {
+		return nil, errors.New("redirect uris are required for the authorization code grant")
+	}
+	if public && slices.Contains(grantTypes, GrantTypeClientCredentials) {
+		return nil, errors.New("public clients cannot use the client credentials grant")
+	}
+
+	return &Client{
+		ID:           uuid.New().String(),
+		Name:         name,
+		RedirectURIs: redirectURIs,
+		GrantTypes:   grantTypes,
+		Scopes:       scopes,
+		Public:       public,
+		IsActive:     true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}, nil
+}
+
+// HasRedirectURI compara de forma exacta, sin normalizar, como exige OAuth 2.1
+func (c *Client) HasRedirectURI(redirectURI string) bool {
+	return slices.Contains(c.RedirectURIs, redirectURI)
+}
+
+// IsServiceAccount indica si el cliente puede autenticarse por sí mismo con client_credentials
+func (c *Client) IsServiceAccount() bool {
+	return c.AllowsGrant(GrantTypeClientCredentials)
+}
+
+func (c *Client) AllowsGrant(grantType string) bool {
+	return slices.Contains(c.GrantTypes, grantType)
+}
+
+// AllowsScopes indica si todos los scopes solicitados están permitidos al cliente
+func (c *Client) AllowsScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// LoginAttempt contador de logins fallidos de una clave (cuenta o IP) dentro de la ventana de observación
+type LoginAttempt struct {
+	Key           string     `json:"key" bson:"_id"`
+	Failures      int        `json:"failures" bson:"failures"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty" bson:"locked_until,omitempty"`
+	LastFailureAt time.Time  `json:"last_failure_at" bson:"last_failure_at"`
+	// ExpiresAt momento en que el contador se descarta si no hay nuevos fallos
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+func (a *LoginAttempt) IsExpired(now time.Time) bool {
+	return !now.Before(a.ExpiresAt)
+}
+
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
@@ -0,0 +1,27 @@
+package entities
+
+// Permisos que el servicio comprueba en sus propios endpoints; otros servicios pueden registrar los suyos
+const (
+	PermissionUsersRead     = "users:read"
+	PermissionUsersWrite    = "users:write"
+	PermissionRolesManage   = "roles:manage"
+	PermissionRolesGrant    = "roles:grant"
+	PermissionKeysManage    = "keys:manage"
+	PermissionClientsManage = "clients:manage"
+)
+
+// Permission acción autorizable; el nombre es el identificador y tiene la forma recurso:acción
+type Permission struct {
+	Name        string `json:"name" bson:"_id"`
+	Description string `json:"description" bson:"description"`
+}
+
+// BuiltinPermissions catálogo de permisos que se registra al iniciar el servicio
+var BuiltinPermissions = []Permission{
+	{Name: PermissionUsersRead, Description: "List and view users"},
+	{Name: PermissionUsersWrite, Description: "Modify, deactivate and delete users"},
+	{Name: PermissionRolesManage, Description: "Create roles and assign their permissions"},
+	{Name: PermissionRolesGrant, Description: "Assign roles to users"},
+	{Name: PermissionKeysManage, Description: "Rotate and retire signing keys"},
+	{Name: PermissionClientsManage, Description: "Register OAuth2 clients"},
+}
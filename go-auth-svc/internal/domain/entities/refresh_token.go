@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken representa un refresh token opaco persistido como hash.
+// Todos los tokens emitidos a partir de un mismo login comparten FamilyID,
+// lo que permite revocar la cadena completa si se detecta reutilización.
+type RefreshToken struct {
+	ID         string     `json:"id" bson:"_id,omitempty"`
+	UserID     string     `json:"user_id" bson:"user_id"`
+	FamilyID   string     `json:"family_id" bson:"family_id"`
+	TokenHash  string     `json:"-" bson:"token_hash"`
+	ExpiresAt  time.Time  `json:"expires_at" bson:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty" bson:"rotated_at,omitempty"`
+	ReplacedBy string     `json:"replaced_by,omitempty" bson:"replaced_by,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+}
+
+// NewRefreshToken crea un token para la familia indicada; si familyID es vacío inicia una nueva familia
+func NewRefreshToken(userID, familyID, tokenHash string, ttl time.Duration) *RefreshToken {
+	if familyID == "" {
+		familyID = uuid.New().String()
+	}
+	now := time.Now()
+	return &RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+func (t *RefreshToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+func (t *RefreshToken) IsRotated() bool {
+	return t.RotatedAt != nil
+}
+
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
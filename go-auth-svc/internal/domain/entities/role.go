@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Role conjunto de permisos asignable a usuarios. Los roles de sistema se crean al iniciar y no pueden eliminarse.
+type Role struct {
+	Name        string    `json:"name" bson:"_id"`
+	Description string    `json:"description" bson:"description"`
+	Permissions []string  `json:"permissions" bson:"permissions"`
+	System      bool      `json:"system" bson:"system"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+func NewRole(name, description string, permissions []string) (*Role, error) {
+	if name == "" {
+		return nil, errors.New("role name is required")
+	}
+	role := &Role{
+		Name:        name,
+		Description: description,
+		CreatedAt:   time.Now(),
+	}
+	role.SetPermissions(permissions)
+	return role, nil
+}
+
+// BuiltinRoles admin recibe todo el catálogo de permisos; user es el rol por defecto del registro y no tiene permisos
+func BuiltinRoles() []*Role {
+	now := time.Now()
+	permissions := make([]string, 0, len(BuiltinPermissions))
+	for _, permission := range BuiltinPermissions {
+		permissions = append(permissions, permission.Name)
+	}
+	return []*Role{
+		{Name: RoleAdmin, Description: "Full administrative access", Permissions: permissions, System: true, CreatedAt: now, UpdatedAt: now},
+		{Name: RoleUser, Description: "Default role for registered users", Permissions: []string{}, System: true, CreatedAt: now, UpdatedAt: now},
+	}
+}
+
+// SetPermissions reemplaza los permisos del rol, ordenados y sin duplicados
+func (r *Role) SetPermissions(permissions []string) {
+	permissions = slices.Clone(permissions)
+	if permissions == nil {
+		permissions = []string{}
+	}
+	slices.Sort(permissions)
+	r.Permissions = slices.Compact(permissions)
+	r.UpdatedAt = time.Now()
+}
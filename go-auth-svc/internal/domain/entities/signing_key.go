@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// Estados de una clave de firma guardada; solo puede haber una activa
+const (
+	SigningKeyStatusActive    = "active"
+	SigningKeyStatusVerifying = "verifying"
+)
+
+// SigningKeyRecord clave de firma de JWT compartida entre réplicas. PrivateKey es la clave privada
+// serializada (PKCS#8, o el secreto en HS256) y cifrada; nunca se guarda en claro.
+type SigningKeyRecord struct {
+	ID         string    `json:"id" bson:"_id"`
+	Algorithm  string    `json:"algorithm" bson:"algorithm"`
+	PrivateKey string    `json:"-" bson:"private_key"`
+	Status     string    `json:"status" bson:"status"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+	// RetiresAt fecha desde la que la clave deja de verificar; vacía en la clave activa
+	RetiresAt *time.Time `json:"retires_at,omitempty" bson:"retires_at,omitempty"`
+}
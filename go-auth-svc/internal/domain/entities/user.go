@@ -2,8 +2,11 @@ package entities
 
 import (
 	"errors"
+	"slices"
 	"time"
 
+	"poc-auth-svc/internal/domain/valueobjects"
+
 	"github.com/google/uuid"
 )
 
@@ -15,10 +18,36 @@ type User struct {
 	IsActive  bool      `json:"is_active" bson:"is_active"`
 	CreatedAt time.Time `json:"" bson:"created_at"`
 	UpdatedAt time.Time `json:"" bson:"updated_at"`
+
+	// EmailCanonical forma canónica del email (valueobjects.Email); es la que se busca y la que debe ser única
+	EmailCanonical string `json:"-" bson:"email_canonical"`
+
+	// Roles asignados; Role guarda el principal (Roles[0]) y es lo único que tienen los documentos anteriores
+	Roles []string `json:"roles" bson:"roles"`
+
+	// Locale idioma preferido para mensajes y notificaciones; vacío usa el de la petición
+	Locale string `json:"locale" bson:"locale"`
+
+	// PasswordResetRequired bloquea el login con contraseña hasta que el usuario la restablezca
+	PasswordResetRequired bool `json:"password_reset_required" bson:"password_reset_required"`
+
+	// PasswordHistory hashes de contraseñas anteriores, de la más reciente a la más antigua
+	PasswordHistory []string `json:"-" bson:"password_history"`
+
+	EmailVerified   bool       `json:"email_verified" bson:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" bson:"email_verified_at,omitempty"`
+
+	// Segundo factor TOTP: los secretos se guardan cifrados y los códigos de recuperación como hash.
+	// Sin omitempty para que Update pueda limpiarlos al deshabilitar MFA.
+	MFAEnabled        bool     `json:"mfa_enabled" bson:"mfa_enabled"`
+	TOTPSecret        string   `json:"-" bson:"totp_secret"`
+	TOTPPendingSecret string   `json:"-" bson:"totp_pending_secret"`
+	TOTPLastStep      int64    `json:"-" bson:"totp_last_step"`
+	RecoveryCodes     []string `json:"-" bson:"recovery_codes"`
 }
 
-func NewUser(email, password, role string) (*User, error) {
-	if email == "" {
+func NewUser(email valueobjects.Email, password string, roles []string) (*User, error) {
+	if email.IsZero() {
 		return nil, errors.New("email is required")
 	}
 
@@ -26,19 +55,58 @@ func NewUser(email, password, role string) (*User, error) {
 		return nil, errors.New("password is required")
 	}
 
-	if role == "" {
-		role = "user"
-	}
-
-	return &User{
+	user := &User{
 		ID:        uuid.New().String(),
-		Email:     email,
 		Password:  password,
-		Role:      role,
 		IsActive:  true,
 		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}, nil
+	}
+	user.SetEmail(email)
+	user.SetRoles(roles)
+	return user, nil
+}
+
+// SetEmail guarda las dos formas del email
+func (u *User) SetEmail(email valueobjects.Email) {
+	u.Email = email.Display()
+	u.EmailCanonical = email.Canonical()
+	u.UpdatedAt = time.Now()
+}
+
+// SetRoles reemplaza los roles del usuario; sin roles queda con el rol por defecto
+func (u *User) SetRoles(roles []string) {
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if role != "" && !slices.Contains(normalized, role) {
+			normalized = append(normalized, role)
+		}
+	}
+	if len(normalized) == 0 {
+		normalized = append(normalized, RoleUser)
+	}
+	u.Roles = normalized
+	u.Role = normalized[0]
+	u.UpdatedAt = time.Now()
+}
+
+// RoleNames roles efectivos del usuario, contemplando los documentos que solo tienen Role
+func (u *User) RoleNames() []string {
+	if len(u.Roles) > 0 {
+		return u.Roles
+	}
+	if u.Role != "" {
+		return []string{u.Role}
+	}
+	return []string{RoleUser}
+}
+
+func (u *User) HasRole(role string) bool {
+	return slices.Contains(u.RoleNames(), role)
+}
+
+func (u *User) SetLocale(locale string) {
+	u.Locale = locale
+	u.UpdatedAt = time.Now()
 }
 
 func (u *User) Deactivate() {
@@ -56,6 +124,78 @@ func (u *User) UpdatePassword(newPassword string) error {
 		return errors.New("password cannot be empty")
 	}
 	u.Password = newPassword
+	u.PasswordResetRequired = false
 	u.UpdatedAt = time.Now()
 	return nil
 }
+
+// RequirePasswordReset obliga al usuario a restablecer la contraseña antes de volver a iniciar sesión
+func (u *User) RequirePasswordReset() {
+	u.PasswordResetRequired = true
+	u.UpdatedAt = time.Now()
+}
+
+// RememberPassword guarda el hash actual en el historial antes de reemplazarlo, conservando como máximo limit
+func (u *User) RememberPassword(limit int) {
+	if limit <= 0 {
+		u.PasswordHistory = nil
+		return
+	}
+	if u.Password == "" {
+		return
+	}
+	history := append([]string{u.Password}, u.PasswordHistory...)
+	if len(history) > limit {
+		history = history[:limit]
+	}
+	u.PasswordHistory = history
+}
+
+// MarkEmailVerified registra que el usuario demostró ser dueño del email
+func (u *User) MarkEmailVerified() {
+	now := time.Now()
+	u.EmailVerified = true
+	u.EmailVerifiedAt = &now
+	u.UpdatedAt = now
+}
+
+// StartMFAEnrollment guarda el secreto cifrado pendiente de confirmación
+func (u *User) StartMFAEnrollment(encryptedSecret string) {
+	u.TOTPPendingSecret = encryptedSecret
+	u.UpdatedAt = time.Now()
+}
+
+// EnableMFA activa el secreto pendiente junto con los hashes de los códigos de recuperación
+func (u *User) EnableMFA(recoveryCodeHashes []string, step int64) error {
+	if u.TOTPPendingSecret == "" {
+		return errors.New("mfa enrollment not started")
+	}
+	u.MFAEnabled = true
+	u.TOTPSecret = u.TOTPPendingSecret
+	u.TOTPPendingSecret = ""
+	u.TOTPLastStep = step
+	u.RecoveryCodes = recoveryCodeHashes
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+func (u *User) DisableMFA() {
+	u.MFAEnabled = false
+	u.TOTPSecret = ""
+	u.TOTPPendingSecret = ""
+	u.TOTPLastStep = 0
+	u.RecoveryCodes = nil
+	u.UpdatedAt = time.Now()
+}
+
+// ConsumeRecoveryCode elimina el código de recuperación usado para que no pueda reutilizarse
+func (u *User) ConsumeRecoveryCode(index int) {
+	u.RecoveryCodes = append(u.RecoveryCodes[:index], u.RecoveryCodes[index+1:]...)
+	u.UpdatedAt = time.Now()
+}
+
+// RecordTOTPStep registra el último paso TOTP aceptado para impedir la reutilización del código
+func (u *User) RecordTOTPStep(step int64) {
+	u.TOTPLastStep = step
+	u.UpdatedAt = time.Now()
+}
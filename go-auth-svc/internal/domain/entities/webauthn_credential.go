@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrSignCountRegression indica que el autenticador informó un contador menor o igual al guardado
+var ErrSignCountRegression = errors.New("webauthn sign count did not increase")
+
+// WebAuthnCredential credencial de clave pública (passkey) registrada por un usuario
+type WebAuthnCredential struct {
+	ID     string `json:"id" bson:"_id,omitempty"`
+	UserID string `json:"user_id" bson:"user_id"`
+	// CredentialID identificador asignado por el autenticador, en base64url sin padding
+	CredentialID string `json:"credential_id" bson:"credential_id"`
+	// PublicKey clave pública en formato COSE tal como la entregó el autenticador
+	PublicKey         []byte     `json:"-" bson:"public_key"`
+	SignCount         uint32     `json:"sign_count" bson:"sign_count"`
+	AAGUID            string     `json:"aaguid" bson:"aaguid"`
+	AttestationFormat string     `json:"attestation_format" bson:"attestation_format"`
+	Transports        []string   `json:"transports,omitempty" bson:"transports,omitempty"`
+	Name              string     `json:"name" bson:"name"`
+	CreatedAt         time.Time  `json:"created_at" bson:"created_at"`
+	LastUsedAt        *time.Time `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+}
+
+func NewWebAuthnCredential(userID, credentialID string, publicKey []byte, signCount uint32, aaguid, attestationFormat string, transports []string, name string) *WebAuthnCredential {
+	if name == "" {
+		name = "Passkey"
+	}
+	return &WebAuthnCredential{
+		ID:                uuid.New().String(),
+		UserID:            userID,
+		CredentialID:      credentialID,
+		PublicKey:         publicKey,
+		SignCount:         signCount,
+		AAGUID:            aaguid,
+		AttestationFormat: attestationFormat,
+		Transports:        transports,
+		Name:              name,
+		CreatedAt:         time.Now(),
+	}
+}
+
+// RecordUse actualiza el contador de firmas. Los autenticadores que no implementan contador
+// informan siempre 0; en cualquier otro caso el contador debe crecer o la credencial pudo ser clonada.
+func (c *WebAuthnCredential) RecordUse(signCount uint32) error {
+	if (signCount != 0 || c.SignCount != 0) && signCount <= c.SignCount {
+		return ErrSignCountRegression
+	}
+	now := time.Now()
+	c.SignCount = signCount
+	c.LastUsedAt = &now
+	return nil
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+// Ceremonias WebAuthn; coinciden con el campo "type" de clientDataJSON
+const (
+	WebAuthnCeremonyRegistration   = "webauthn.create"
+	WebAuthnCeremonyAuthentication = "webauthn.get"
+)
+
+// WebAuthnSession desafío emitido al iniciar una ceremonia WebAuthn; se consume una sola vez al finalizarla
+type WebAuthnSession struct {
+	// Challenge desafío aleatorio en base64url sin padding; es también el identificador de la sesión
+	Challenge string `json:"challenge" bson:"_id"`
+	// UserID usuario esperado; vacío en un login sin email, donde el usuario lo indica la credencial
+	UserID    string    `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	Ceremony  string    `json:"ceremony" bson:"ceremony"`
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+func NewWebAuthnSession(challenge, userID, ceremony string, ttl time.Duration) *WebAuthnSession {
+	return &WebAuthnSession{
+		Challenge: challenge,
+		UserID:    userID,
+		Ceremony:  ceremony,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+func (s *WebAuthnSession) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
@@ -4,11 +4,83 @@ type ErrorCode string
 
 const (
 	//User domain errors
-	UserNotFound      ErrorCode = "USER_NOT_FOUND"
-	UserAlreadyExists ErrorCode = "USER_ALREADY_EXISTS"
-	UserInactive      ErrorCode = "USER_INACTIVE"
+	UserNotFound              ErrorCode = "USER_NOT_FOUND"
+	UserAlreadyExists         ErrorCode = "USER_ALREADY_EXISTS"
+	UserInactive              ErrorCode = "USER_INACTIVE"
+	EmailNotVerified          ErrorCode = "EMAIL_NOT_VERIFIED"
+	AccountLocked             ErrorCode = "ACCOUNT_LOCKED"
+	PasswordResetRequired     ErrorCode = "PASSWORD_RESET_REQUIRED"
+	SelfModificationForbidden ErrorCode = "SELF_MODIFICATION_FORBIDDEN"
+	InvalidEmail              ErrorCode = "INVALID_EMAIL"
+
+	//RBAC domain errors
+	RoleNotFound       ErrorCode = "ROLE_NOT_FOUND"
+	RoleAlreadyExists  ErrorCode = "ROLE_ALREADY_EXISTS"
+	RoleProtected      ErrorCode = "ROLE_PROTECTED"
+	PermissionNotFound ErrorCode = "PERMISSION_NOT_FOUND"
+	PermissionDenied   ErrorCode = "PERMISSION_DENIED"
+	// UnauthorizedRoleGrant se intentó otorgar un rol con permisos que quien lo otorga no tiene
+	UnauthorizedRoleGrant ErrorCode = "UNAUTHORIZED_ROLE_GRANT"
+
+	//Email verification domain errors
+	EmailVerificationTokenInvalid ErrorCode = "EMAIL_VERIFICATION_TOKEN_INVALID"
+
+	//Token domain errors
+	RefreshTokenInvalid ErrorCode = "REFRESH_TOKEN_INVALID"
+	RefreshTokenExpired ErrorCode = "REFRESH_TOKEN_EXPIRED"
+	RefreshTokenReused  ErrorCode = "REFRESH_TOKEN_REUSED"
+	// AccessTokenInvalid access token mal formado, vencido, sin sujeto o revocado
+	AccessTokenInvalid ErrorCode = "ACCESS_TOKEN_INVALID"
+	// AuthenticationRequired la request no trae un bearer token
+	AuthenticationRequired ErrorCode = "AUTHENTICATION_REQUIRED"
+
+	//Signing key errors
+	SigningKeyNotFound         ErrorCode = "SIGNING_KEY_NOT_FOUND"
+	ActiveSigningKeyRetirement ErrorCode = "ACTIVE_SIGNING_KEY_RETIREMENT"
+
+	//Password domain errors
+	PasswordResetTokenInvalid ErrorCode = "PASSWORD_RESET_TOKEN_INVALID"
+	CurrentPasswordInvalid    ErrorCode = "CURRENT_PASSWORD_INVALID"
+
+	//Password policy domain errors
+	PasswordTooShort         ErrorCode = "PASSWORD_TOO_SHORT"
+	PasswordTooLong          ErrorCode = "PASSWORD_TOO_LONG"
+	PasswordMissingUppercase ErrorCode = "PASSWORD_MISSING_UPPERCASE"
+	PasswordMissingLowercase ErrorCode = "PASSWORD_MISSING_LOWERCASE"
+	PasswordMissingDigit     ErrorCode = "PASSWORD_MISSING_DIGIT"
+	PasswordMissingSymbol    ErrorCode = "PASSWORD_MISSING_SYMBOL"
+	PasswordContainsEmail    ErrorCode = "PASSWORD_CONTAINS_EMAIL"
+	PasswordTooCommon        ErrorCode = "PASSWORD_TOO_COMMON"
+	PasswordBreached         ErrorCode = "PASSWORD_BREACHED"
+	PasswordTooWeak          ErrorCode = "PASSWORD_TOO_WEAK"
+	PasswordReused           ErrorCode = "PASSWORD_REUSED"
+
+	//MFA domain errors
+	MFAAlreadyEnabled     ErrorCode = "MFA_ALREADY_ENABLED"
+	MFANotEnabled         ErrorCode = "MFA_NOT_ENABLED"
+	MFAEnrollmentNotFound ErrorCode = "MFA_ENROLLMENT_NOT_FOUND"
+	MFAInvalidCode        ErrorCode = "MFA_INVALID_CODE"
+	MFAChallengeInvalid   ErrorCode = "MFA_CHALLENGE_INVALID"
+
+	//WebAuthn domain errors
+	WebAuthnChallengeInvalid   ErrorCode = "WEBAUTHN_CHALLENGE_INVALID"
+	WebAuthnVerificationFailed ErrorCode = "WEBAUTHN_VERIFICATION_FAILED"
+	WebAuthnCredentialNotFound ErrorCode = "WEBAUTHN_CREDENTIAL_NOT_FOUND"
+	WebAuthnCredentialExists   ErrorCode = "WEBAUTHN_CREDENTIAL_EXISTS"
+	WebAuthnCredentialCloned   ErrorCode = "WEBAUTHN_CREDENTIAL_CLONED"
+
+	//OAuth2 domain errors
+	InvalidClient            ErrorCode = "INVALID_CLIENT"
+	InvalidRedirectURI       ErrorCode = "INVALID_REDIRECT_URI"
+	InvalidScope             ErrorCode = "INVALID_SCOPE"
+	UnauthorizedGrant        ErrorCode = "UNAUTHORIZED_GRANT"
+	InvalidAuthorizationCode ErrorCode = "INVALID_AUTHORIZATION_CODE"
+	InvalidCodeVerifier      ErrorCode = "INVALID_CODE_VERIFIER"
 
 	//Generic domain errors
-	ValidationFailed   ErrorCode = "VALIDATION_FAILED"
-	InvalidCredentials ErrorCode = "INVALID_CREDENTIALS"
+	ValidationFailed ErrorCode = "VALIDATION_FAILED"
+	// UnsupportedMediaType el cuerpo de la request no tiene el Content-Type esperado
+	UnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	InvalidCredentials   ErrorCode = "INVALID_CREDENTIALS"
+	Internal             ErrorCode = "INTERNAL_ERROR"
 )
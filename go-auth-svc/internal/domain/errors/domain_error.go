@@ -0,0 +1,36 @@
+package errors
+
+// DomainError error de dominio con su código. Message es el texto para el cliente;
+// Cause conserva el error original (por ejemplo del driver de Mongo) solo para logs.
+type DomainError struct {
+	Code    ErrorCode
+	Message string
+	Cause   error
+}
+
+// New crea un DomainError con el mensaje registrado para el código
+func New(code ErrorCode) *DomainError {
+	return &DomainError{Code: code, Message: GetMessage(code)}
+}
+
+// Wrap crea un DomainError que conserva cause; con cause nil equivale a New
+func Wrap(code ErrorCode, cause error) *DomainError {
+	return &DomainError{Code: code, Message: GetMessage(code), Cause: cause}
+}
+
+func (e *DomainError) Error() string {
+	if e.Cause != nil {
+		return e.Message + ": " + e.Cause.Error()
+	}
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Cause
+}
+
+// Is compara por código, de modo que errors.Is(err, ErrX) funciona aunque err se haya creado con Wrap
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Code == e.Code
+}
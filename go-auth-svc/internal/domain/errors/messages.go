@@ -3,11 +3,70 @@ package errors
 import "fmt"
 
 var errorMessages = map[ErrorCode]string{
-	UserNotFound:       "Usuario no encontrado",
-	UserAlreadyExists:  "El usuario ya existe",
-	UserInactive:       "El usuario esta inactivo",
-	ValidationFailed:   "Fallo la validacion de datos",
-	InvalidCredentials: "Credenciales incorrectas",
+	UserNotFound:              "Usuario no encontrado",
+	UserAlreadyExists:         "El usuario ya existe",
+	UserInactive:              "El usuario esta inactivo",
+	EmailNotVerified:          "El email del usuario no esta verificado",
+	AccountLocked:             "Demasiados intentos fallidos, la cuenta esta bloqueada temporalmente",
+	PasswordResetRequired:     "Debe restablecer su contraseña antes de iniciar sesion",
+	SelfModificationForbidden: "No puede desactivar, eliminar ni cambiar los roles de su propia cuenta",
+	InvalidEmail:              "El email no es valido",
+	ValidationFailed:          "Fallo la validacion de datos",
+	UnsupportedMediaType:      "El Content-Type de la request no es valido",
+	InvalidCredentials:        "Credenciales incorrectas",
+	Internal:                  "Error interno del servidor",
+
+	RoleNotFound:          "Rol no encontrado",
+	RoleAlreadyExists:     "El rol ya existe",
+	RoleProtected:         "Los roles de sistema no pueden eliminarse ni cambiar sus permisos",
+	PermissionNotFound:    "Permiso no encontrado",
+	PermissionDenied:      "No tiene permisos para realizar esta accion",
+	UnauthorizedRoleGrant: "No puede otorgar roles con permisos que usted no tiene",
+
+	EmailVerificationTokenInvalid: "El enlace de verificacion de email es invalido o expiro",
+
+	RefreshTokenInvalid:    "Refresh token invalido",
+	RefreshTokenExpired:    "Refresh token expirado",
+	RefreshTokenReused:     "Refresh token reutilizado, la sesion fue revocada",
+	AccessTokenInvalid:     "Token invalido",
+	AuthenticationRequired: "Se requiere autenticacion",
+
+	SigningKeyNotFound:         "Clave de firma no encontrada",
+	ActiveSigningKeyRetirement: "La clave de firma activa no puede retirarse sin rotar antes",
+
+	PasswordResetTokenInvalid: "El token de recuperacion de contraseña es invalido o expiro",
+	CurrentPasswordInvalid:    "La contraseña actual es incorrecta",
+
+	PasswordTooShort:         "La contraseña es demasiado corta",
+	PasswordTooLong:          "La contraseña es demasiado larga",
+	PasswordMissingUppercase: "La contraseña debe incluir una letra mayuscula",
+	PasswordMissingLowercase: "La contraseña debe incluir una letra minuscula",
+	PasswordMissingDigit:     "La contraseña debe incluir un digito",
+	PasswordMissingSymbol:    "La contraseña debe incluir un simbolo",
+	PasswordContainsEmail:    "La contraseña no puede contener el email",
+	PasswordTooCommon:        "La contraseña es demasiado comun",
+	PasswordBreached:         "La contraseña aparece en filtraciones de datos conocidas",
+	PasswordTooWeak:          "La contraseña es demasiado facil de adivinar",
+	PasswordReused:           "La contraseña ya fue usada recientemente",
+
+	MFAAlreadyEnabled:     "El segundo factor ya esta habilitado",
+	MFANotEnabled:         "El segundo factor no esta habilitado",
+	MFAEnrollmentNotFound: "No hay un registro de segundo factor pendiente",
+	MFAInvalidCode:        "Codigo de verificacion invalido",
+	MFAChallengeInvalid:   "El desafio de segundo factor es invalido o expiro",
+
+	WebAuthnChallengeInvalid:   "El desafio WebAuthn es invalido o expiro",
+	WebAuthnVerificationFailed: "No se pudo verificar la respuesta del autenticador",
+	WebAuthnCredentialNotFound: "Credencial WebAuthn no encontrada",
+	WebAuthnCredentialExists:   "La credencial WebAuthn ya esta registrada",
+	WebAuthnCredentialCloned:   "El contador de firmas de la credencial retrocedio, posible autenticador clonado",
+
+	InvalidClient:            "Cliente invalido o no autenticado",
+	InvalidRedirectURI:       "La redirect_uri no esta registrada para el cliente",
+	InvalidScope:             "Scope no permitido para el cliente",
+	UnauthorizedGrant:        "El cliente no tiene permitido este grant type",
+	InvalidAuthorizationCode: "Codigo de autorizacion invalido o expirado",
+	InvalidCodeVerifier:      "El code_verifier no corresponde al code_challenge",
 }
 
 // GetMessage obtiene el mensaje para un código de error
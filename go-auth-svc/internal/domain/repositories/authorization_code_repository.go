@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+var (
+	// ErrAuthorizationCodeNotFound se devuelve cuando el código no existe o ya fue canjeado
+	ErrAuthorizationCodeNotFound = errors.New("authorization code not found")
+)
+
+type AuthorizationCodeRepository interface {
+	Create(ctx context.Context, code *entities.AuthorizationCode) error
+	// Consume marca el código como usado de forma atómica y lo devuelve; un segundo canje devuelve ErrAuthorizationCodeNotFound
+	Consume(ctx context.Context, codeHash string) (*entities.AuthorizationCode, error)
+}
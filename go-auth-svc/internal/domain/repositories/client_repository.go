@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+var (
+	// ErrClientNotFound se devuelve cuando no existe el cliente OAuth2
+	ErrClientNotFound = errors.New("client not found")
+)
+
+type ClientRepository interface {
+	Create(ctx context.Context, client *entities.Client) error
+	GetByID(ctx context.Context, id string) (*entities.Client, error)
+	List(ctx context.Context) ([]*entities.Client, error)
+	Update(ctx context.Context, client *entities.Client) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+type LoginAttemptRepository interface {
+	// Get devuelve el contador vigente de la clave, o nil si no hay fallos recientes
+	Get(ctx context.Context, key string) (*entities.LoginAttempt, error)
+	// RecordFailure incrementa el contador de forma atómica, reiniciándolo si ya había expirado,
+	// extiende su vigencia hasta at+window y devuelve el contador actualizado
+	RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*entities.LoginAttempt, error)
+	// Lock bloquea la clave hasta until; el contador se conserva al menos hasta entonces
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
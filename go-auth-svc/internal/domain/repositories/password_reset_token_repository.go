@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+var (
+	// ErrPasswordResetTokenNotFound se devuelve cuando el token no existe o ya fue usado
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+)
+
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, token *entities.PasswordResetToken) error
+	// GetByHash devuelve el token si aún no fue usado, sin consumirlo
+	GetByHash(ctx context.Context, tokenHash string) (*entities.PasswordResetToken, error)
+	// Consume marca el token como usado de forma atómica y lo devuelve; un segundo uso devuelve ErrPasswordResetTokenNotFound
+	Consume(ctx context.Context, tokenHash string) (*entities.PasswordResetToken, error)
+	// DeleteByUser elimina los tokens pendientes del usuario para que solo el último emitido sea válido
+	DeleteByUser(ctx context.Context, userID string) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+type PermissionRepository interface {
+	// Save crea el permiso o actualiza su descripción
+	Save(ctx context.Context, permission *entities.Permission) error
+	// GetByNames devuelve los permisos existentes entre los nombres indicados
+	GetByNames(ctx context.Context, names []string) ([]*entities.Permission, error)
+	List(ctx context.Context) ([]*entities.Permission, error)
+}
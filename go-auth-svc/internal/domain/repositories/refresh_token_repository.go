@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+var (
+	// ErrRefreshTokenNotFound se devuelve cuando no existe un refresh token con el hash indicado
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entities.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error)
+	// MarkRotated marca el token como usado de forma atómica; devuelve false si ya había sido rotado o revocado
+	MarkRotated(ctx context.Context, id, replacedBy string, at time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
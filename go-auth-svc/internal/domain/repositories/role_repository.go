@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+var (
+	// ErrRoleNotFound se devuelve cuando no existe el rol
+	ErrRoleNotFound = errors.New("role not found")
+	// ErrDuplicateRole se devuelve al crear un rol con un nombre ya usado
+	ErrDuplicateRole = errors.New("role already exists")
+)
+
+type RoleRepository interface {
+	Create(ctx context.Context, role *entities.Role) error
+	// CreateIfNotExists crea el rol solo si no existe, sin pisar los cambios hechos por un administrador
+	CreateIfNotExists(ctx context.Context, role *entities.Role) error
+	GetByName(ctx context.Context, name string) (*entities.Role, error)
+	// GetByNames devuelve los roles existentes entre los nombres indicados; los inexistentes se omiten
+	GetByNames(ctx context.Context, names []string) ([]*entities.Role, error)
+	List(ctx context.Context) ([]*entities.Role, error)
+	Update(ctx context.Context, role *entities.Role) error
+	Delete(ctx context.Context, name string) error
+}
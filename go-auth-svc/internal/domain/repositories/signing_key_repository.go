@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+var (
+	// ErrSigningKeyConflict se devuelve cuando el kid ya existe, cuando ya hay otra clave activa
+	// o cuando otra réplica rotó la clave activa antes
+	ErrSigningKeyConflict = errors.New("signing key conflicts with the stored key ring")
+	ErrSigningKeyNotFound = errors.New("signing key not found")
+)
+
+type SigningKeyRepository interface {
+	// List devuelve las claves activa y de verificación que no fueron eliminadas
+	List(ctx context.Context) ([]*entities.SigningKeyRecord, error)
+	Create(ctx context.Context, key *entities.SigningKeyRecord) error
+	// Rotate deja previousID solo verificando hasta retiresAt y guarda next como activa. Devuelve
+	// ErrSigningKeyConflict si previousID ya no es la clave activa.
+	Rotate(ctx context.Context, previousID string, next *entities.SigningKeyRecord, retiresAt time.Time) error
+	// Retire adelanta el retiro de una clave de verificación; la clave activa no puede retirarse
+	Retire(ctx context.Context, id string, retiresAt time.Time) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationRepository lista de access tokens revocados antes de su expiración.
+// Cada entrada vive solo hasta expiresAt, momento en que el token ya no sería válido de todas formas.
+type TokenRevocationRepository interface {
+	// RevokeToken revoca un único token identificado por su jti
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	// RevokeUser revoca todos los tokens del usuario emitidos antes de issuedBefore
+	RevokeUser(ctx context.Context, userID string, issuedBefore, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error)
+}
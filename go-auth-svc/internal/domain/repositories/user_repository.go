@@ -3,21 +3,70 @@ package repositories
 import (
 	"context"
 	"errors"
+	"time"
 
 	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/valueobjects"
 )
 
 var (
 	// ErrUserNotFound se devuelve cuando no se encuentra un usuario
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound = err_domain.New(err_domain.UserNotFound)
 	// ErrDuplicateEmail se devuelve cuando el email ya existe
-	ErrDuplicateEmail = errors.New("email already exists")
+	ErrDuplicateEmail = err_domain.New(err_domain.UserAlreadyExists)
+	// ErrInvalidCursor se devuelve cuando el cursor de paginación está mal formado o no corresponde al orden pedido
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
 )
 
+// UserFilter criterios de búsqueda de usuarios; los campos vacíos no filtran
+type UserFilter struct {
+	// EmailPrefix prefijo de la forma canónica del email, sin distinguir mayúsculas
+	EmailPrefix   string
+	Role          string
+	IsActive      *bool
+	EmailVerified *bool
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	UpdatedFrom   *time.Time
+	UpdatedTo     *time.Time
+}
+
+// UserSortField campos por los que puede ordenarse el listado de usuarios
+type UserSortField string
+
+const (
+	UserSortCreatedAt UserSortField = "created_at"
+	UserSortUpdatedAt UserSortField = "updated_at"
+	// UserSortEmail ordena por la forma canónica del email, sin distinguir mayúsculas
+	UserSortEmail UserSortField = "email"
+)
+
+// Page ventana de resultados basada en cursor. Cursor es el NextCursor de la página anterior
+// y solo es válido con el mismo orden con el que se obtuvo.
+type Page struct {
+	Cursor     string
+	Limit      int
+	SortBy     UserSortField
+	Descending bool
+}
+
+// UserPage resultado de un listado. Total cuenta todos los usuarios que cumplen el filtro,
+// no solo los de la página; NextCursor queda vacío en la última página.
+type UserPage struct {
+	Users      []*entities.User
+	NextCursor string
+	Total      int64
+}
+
 type UserRepository interface {
+	// Create devuelve ErrDuplicateEmail si ya hay un usuario con la misma forma canónica del email
 	Create(ctx context.Context, user *entities.User) error
-	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+	// GetByEmail busca por la forma canónica del email
+	GetByEmail(ctx context.Context, email valueobjects.Email) (*entities.User, error)
 	GetByID(ctx context.Context, id string) (*entities.User, error)
 	Update(ctx context.Context, user *entities.User) error
 	Delete(ctx context.Context, id string) error
+	// List devuelve los usuarios que cumplen el filtro; sin SortBy se ordena por fecha de creación
+	List(ctx context.Context, filter UserFilter, page Page) (*UserPage, error)
 }
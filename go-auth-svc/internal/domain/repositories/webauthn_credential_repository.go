@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+var (
+	ErrWebAuthnCredentialNotFound = errors.New("webauthn credential not found")
+	// ErrDuplicateWebAuthnCredential se devuelve cuando el credential ID ya está registrado
+	ErrDuplicateWebAuthnCredential = errors.New("webauthn credential already registered")
+)
+
+type WebAuthnCredentialRepository interface {
+	Create(ctx context.Context, credential *entities.WebAuthnCredential) error
+	GetByCredentialID(ctx context.Context, credentialID string) (*entities.WebAuthnCredential, error)
+	ListByUser(ctx context.Context, userID string) ([]*entities.WebAuthnCredential, error)
+	// UpdateSignCount guarda el contador y la fecha de último uso después de una autenticación
+	UpdateSignCount(ctx context.Context, credential *entities.WebAuthnCredential) error
+	Delete(ctx context.Context, userID, id string) error
+	DeleteByUser(ctx context.Context, userID string) error
+}
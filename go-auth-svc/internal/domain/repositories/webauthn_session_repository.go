@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+var (
+	// ErrWebAuthnSessionNotFound se devuelve cuando el desafío no existe o ya fue usado
+	ErrWebAuthnSessionNotFound = errors.New("webauthn session not found")
+)
+
+type WebAuthnSessionRepository interface {
+	Create(ctx context.Context, session *entities.WebAuthnSession) error
+	// Consume elimina la sesión de forma atómica y la devuelve; un segundo intento devuelve ErrWebAuthnSessionNotFound
+	Consume(ctx context.Context, challenge string) (*entities.WebAuthnSession, error)
+}
@@ -7,66 +7,184 @@ import (
 	"poc-auth-svc/internal/domain/entities"
 	err_domain "poc-auth-svc/internal/domain/errors"
 	"poc-auth-svc/internal/domain/repositories"
+	"poc-auth-svc/internal/domain/valueobjects"
+)
+
+var (
+	ErrUserAlreadyExists     = err_domain.New(err_domain.UserAlreadyExists)
+	ErrUserInactive          = err_domain.New(err_domain.UserInactive)
+	ErrInvalidCredentials    = err_domain.New(err_domain.InvalidCredentials)
+	ErrEmailNotVerified      = err_domain.New(err_domain.EmailNotVerified)
+	ErrPasswordResetRequired = err_domain.New(err_domain.PasswordResetRequired)
 )
 
 type AuthService interface {
-	Register(ctx context.Context, email, password, role string) (*entities.User, error)
-	Login(ctx context.Context, email, password string) (*entities.User, error)
+	// Register crea el usuario con los roles indicados, o con el rol por defecto si no se indica ninguno
+	Register(ctx context.Context, email, password string, roles []string, locale string) (*entities.User, error)
+	// Login verifica las credenciales; clientIP alimenta el bloqueo por fuerza bruta y puede ir vacío
+	Login(ctx context.Context, email, password, clientIP string) (*entities.User, error)
 	GetUserByID(ctx context.Context, id string) (*entities.User, error)
+	// CheckLoginPolicy reglas que el usuario debe cumplir después de autenticarse con cualquier mecanismo
+	// (contraseña, segundo factor, OIDC o passkey) antes de emitir una sesión: cuenta activa, sin
+	// restablecimiento de contraseña pendiente y, si AuthPolicy lo exige, email verificado
+	CheckLoginPolicy(user *entities.User) error
+	// UpdateLocale guarda el idioma preferido; vacío vuelve a usar el de cada petición
+	UpdateLocale(ctx context.Context, userID, locale string) (*entities.User, error)
 }
 
 type PasswordHasher interface {
 	Hash(password string) (string, error)
 	Compare(hashedPassword, password string) bool
+	// NeedsRehash indica si el hash fue generado con otro algoritmo o con parámetros más débiles que los actuales
+	NeedsRehash(hashedPassword string) bool
+}
+
+// AuthPolicy reglas configurables del login
+type AuthPolicy struct {
+	// RequireVerifiedEmail rechaza el login de usuarios que no verificaron su email
+	RequireVerifiedEmail bool
+	// EmailRules canonicalización de los emails, la misma con la que se migraron los usuarios existentes
+	EmailRules valueobjects.EmailRules
 }
 
 type authService struct {
-	userRepo repositories.UserRepository
-	hasher   PasswordHasher
+	userRepo       repositories.UserRepository
+	hasher         PasswordHasher
+	throttle       LoginThrottleService
+	passwordPolicy PasswordPolicyService
+	policy         AuthPolicy
 }
 
-func NewAuthService(userRepo repositories.UserRepository, hasher PasswordHasher) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, hasher PasswordHasher, throttle LoginThrottleService, passwordPolicy PasswordPolicyService, policy AuthPolicy) AuthService {
 	return &authService{
-		userRepo: userRepo,
-		hasher:   hasher,
+		userRepo:       userRepo,
+		hasher:         hasher,
+		throttle:       throttle,
+		passwordPolicy: passwordPolicy,
+		policy:         policy,
 	}
 }
 
-func (s *authService) Register(ctx context.Context, email, password, role string) (*entities.User, error) {
-
-	existingUser, _ := s.userRepo.GetByEmail(ctx, email)
-	if existingUser != nil {
-		return nil, errors.New(err_domain.GetMessage(err_domain.UserAlreadyExists)) //repositories.ErrDuplicateEmail
+func (s *authService) Register(ctx context.Context, email, password string, roles []string, locale string) (*entities.User, error) {
+	address, err := valueobjects.NewEmail(email, s.policy.EmailRules)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.passwordPolicy.Validate(ctx, password, address.Display()); err != nil {
+		return nil, err
 	}
 	hashedPassword, err := s.hasher.Hash(password)
 	if err != nil {
-		return nil, err
+		return nil, err_domain.Wrap(err_domain.Internal, err)
 	}
 
-	user, err := entities.NewUser(email, hashedPassword, role)
+	user, err := entities.NewUser(address, hashedPassword, roles)
 	if err != nil {
-		return nil, err
+		return nil, err_domain.Wrap(err_domain.ValidationFailed, err)
 	}
+	user.Locale = locale
+	// El índice único del repositorio es la única comprobación: consultar antes no evita la carrera entre dos registros
 	if err := s.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateEmail) {
+			return nil, ErrUserAlreadyExists
+		}
 		return nil, err
 	}
 	return user, nil
 }
 
-func (s *authService) Login(ctx context.Context, email, password string) (*entities.User, error) {
-	user, err := s.userRepo.GetByEmail(ctx, email)
-	if err != nil {
-		return nil, errors.New(err_domain.GetMessage(err_domain.InvalidCredentials))
+func (s *authService) Login(ctx context.Context, email, password, clientIP string) (*entities.User, error) {
+	// El bloqueo va por la forma canónica para que las variantes de un mismo email compartan el contador
+	address, emailErr := valueobjects.NewEmail(email, s.policy.EmailRules)
+	account := email
+	if emailErr == nil {
+		account = address.Canonical()
 	}
-	if !user.IsActive {
-		return nil, errors.New(err_domain.GetMessage(err_domain.UserInactive))
+	// Durante el bloqueo se rechaza incluso la contraseña correcta
+	if err := s.throttle.Check(ctx, account, clientIP); err != nil {
+		return nil, err
+	}
+	if emailErr != nil {
+		return nil, s.loginFailed(ctx, account, clientIP)
+	}
+	user, err := s.userRepo.GetByEmail(ctx, address)
+	if errors.Is(err, repositories.ErrUserNotFound) {
+		// Los emails inexistentes también cuentan para no distinguirlos de las cuentas reales
+		return nil, s.loginFailed(ctx, account, clientIP)
+	}
+	if err != nil {
+		return nil, err
 	}
 	if ok := s.hasher.Compare(user.Password, password); !ok {
-		return nil, errors.New(err_domain.GetMessage(err_domain.InvalidCredentials))
+		return nil, s.loginFailed(ctx, account, clientIP)
+	}
+	// Con segundo factor el contador se reinicia recién al completarlo (MFAService.VerifyLogin): si la
+	// contraseña correcta lo reiniciara, adivinar el código no consumiría intentos
+	if !user.MFAEnabled {
+		if err := s.throttle.RecordSuccess(ctx, account); err != nil {
+			return nil, err
+		}
+	}
+	// Se comprueba después de la contraseña para no revelar el estado de cuentas ajenas
+	if err := s.CheckLoginPolicy(user); err != nil {
+		return nil, err
 	}
+	s.upgradePasswordHash(ctx, user, password)
 	return user, nil
 }
 
+func (s *authService) CheckLoginPolicy(user *entities.User) error {
+	if !user.IsActive {
+		return ErrUserInactive
+	}
+	if user.PasswordResetRequired {
+		return ErrPasswordResetRequired
+	}
+	if s.policy.RequireVerifiedEmail && !user.EmailVerified {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// upgradePasswordHash regenera el hash con la configuración actual aprovechando que se conoce la contraseña.
+// Es best effort: si falla, el login continúa y se vuelve a intentar en el próximo.
+func (s *authService) upgradePasswordHash(ctx context.Context, user *entities.User, password string) {
+	if !s.hasher.NeedsRehash(user.Password) {
+		return
+	}
+	hashedPassword, err := s.hasher.Hash(password)
+	if err != nil {
+		return
+	}
+	previous := user.Password
+	if err := user.UpdatePassword(hashedPassword); err != nil {
+		return
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		user.Password = previous
+	}
+}
+
+// loginFailed registra el intento fallido y devuelve el error de credenciales
+func (s *authService) loginFailed(ctx context.Context, email, clientIP string) error {
+	if err := s.throttle.RecordFailure(ctx, email, clientIP); err != nil {
+		return err
+	}
+	return ErrInvalidCredentials
+}
+
 func (s *authService) GetUserByID(ctx context.Context, id string) (*entities.User, error) {
 	return s.userRepo.GetByID(ctx, id)
 }
+
+func (s *authService) UpdateLocale(ctx context.Context, userID, locale string) (*entities.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.SetLocale(locale)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
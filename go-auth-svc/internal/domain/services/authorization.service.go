@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+)
+
+var (
+	ErrRoleNotFound       = err_domain.New(err_domain.RoleNotFound)
+	ErrRoleAlreadyExists  = err_domain.New(err_domain.RoleAlreadyExists)
+	ErrRoleProtected      = err_domain.New(err_domain.RoleProtected)
+	ErrPermissionNotFound = err_domain.New(err_domain.PermissionNotFound)
+	ErrPermissionDenied   = err_domain.New(err_domain.PermissionDenied)
+	// ErrUnauthorizedRoleGrant distinto de ErrUnauthorizedGrant, que es el error OAuth de grant types
+	ErrUnauthorizedRoleGrant = err_domain.New(err_domain.UnauthorizedRoleGrant)
+)
+
+// AuthorizationService administra roles y permisos y resuelve los permisos efectivos de cada usuario
+type AuthorizationService interface {
+	// EnsureBuiltins registra el catálogo de permisos y crea los roles de sistema que falten
+	EnsureBuiltins(ctx context.Context) error
+	// Permissions une los permisos de los roles indicados; los roles inexistentes no aportan permisos
+	Permissions(ctx context.Context, roles []string) ([]string, error)
+	HasPermission(ctx context.Context, user *entities.User, permission string) (bool, error)
+	// ValidateRoles devuelve ErrRoleNotFound si alguno de los roles no existe
+	ValidateRoles(ctx context.Context, roles []string) error
+	// AuthorizeRoleGrant comprueba que grantor pueda otorgar los roles: necesita roles:grant (ErrPermissionDenied),
+	// los roles deben existir (ErrRoleNotFound) y sus permisos deben ser un subconjunto de los de grantor
+	// (ErrUnauthorizedRoleGrant), para que nadie pueda otorgar más de lo que tiene
+	AuthorizeRoleGrant(ctx context.Context, grantor *entities.User, roles []string) error
+	// AuthorizeUserManagement exige que actor tenga todos los permisos de user (ErrPermissionDenied), para que
+	// un administrador no pueda desactivar, eliminar, degradar ni forzar el cambio de contraseña de otro con más permisos
+	AuthorizeUserManagement(ctx context.Context, actor, user *entities.User) error
+	ListRoles(ctx context.Context) ([]*entities.Role, error)
+	// CreateRole, UpdateRole y DeleteRole solo admiten roles cuyos permisos tenga actor, antes y después
+	// del cambio (ErrUnauthorizedRoleGrant); si no, quien administra roles podría ampliar sus propios
+	// permisos a través de un rol que ya tiene. Los permisos de los roles de sistema no pueden cambiarse
+	// (ErrRoleProtected).
+	CreateRole(ctx context.Context, actor *entities.User, name, description string, permissions []string) (*entities.Role, error)
+	UpdateRole(ctx context.Context, actor *entities.User, name, description string, permissions []string) (*entities.Role, error)
+	DeleteRole(ctx context.Context, actor *entities.User, name string) error
+	ListPermissions(ctx context.Context) ([]*entities.Permission, error)
+}
+
+type authorizationService struct {
+	roleRepo       repositories.RoleRepository
+	permissionRepo repositories.PermissionRepository
+}
+
+func NewAuthorizationService(roleRepo repositories.RoleRepository, permissionRepo repositories.PermissionRepository) AuthorizationService {
+	return &authorizationService{
+		roleRepo:       roleRepo,
+		permissionRepo: permissionRepo,
+	}
+}
+
+func (s *authorizationService) EnsureBuiltins(ctx context.Context) error {
+	for _, permission := range entities.BuiltinPermissions {
+		if err := s.permissionRepo.Save(ctx, &permission); err != nil {
+			return err
+		}
+	}
+	for _, role := range entities.BuiltinRoles() {
+		if err := s.roleRepo.CreateIfNotExists(ctx, role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *authorizationService) Permissions(ctx context.Context, roles []string) ([]string, error) {
+	found, err := s.roleRepo.GetByNames(ctx, roles)
+	if err != nil {
+		return nil, err
+	}
+	permissions := make([]string, 0)
+	for _, role := range found {
+		permissions = append(permissions, role.Permissions...)
+	}
+	slices.Sort(permissions)
+	return slices.Compact(permissions), nil
+}
+
+func (s *authorizationService) HasPermission(ctx context.Context, user *entities.User, permission string) (bool, error) {
+	permissions, err := s.Permissions(ctx, user.RoleNames())
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(permissions, permission), nil
+}
+
+func (s *authorizationService) ValidateRoles(ctx context.Context, roles []string) error {
+	found, err := s.roleRepo.GetByNames(ctx, roles)
+	if err != nil {
+		return err
+	}
+	for _, name := range roles {
+		if !slices.ContainsFunc(found, func(role *entities.Role) bool { return role.Name == name }) {
+			return ErrRoleNotFound
+		}
+	}
+	return nil
+}
+
+func (s *authorizationService) AuthorizeRoleGrant(ctx context.Context, grantor *entities.User, roles []string) error {
+	grantorPermissions, err := s.Permissions(ctx, grantor.RoleNames())
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(grantorPermissions, entities.PermissionRolesGrant) {
+		return ErrPermissionDenied
+	}
+	if err := s.ValidateRoles(ctx, roles); err != nil {
+		return err
+	}
+	granted, err := s.Permissions(ctx, roles)
+	if err != nil {
+		return err
+	}
+	for _, permission := range granted {
+		if !slices.Contains(grantorPermissions, permission) {
+			return ErrUnauthorizedRoleGrant
+		}
+	}
+	return nil
+}
+
+func (s *authorizationService) AuthorizeUserManagement(ctx context.Context, actor, user *entities.User) error {
+	permissions, err := s.Permissions(ctx, user.RoleNames())
+	if err != nil {
+		return err
+	}
+	if err := s.authorizePermissions(ctx, actor, permissions); errors.Is(err, ErrUnauthorizedRoleGrant) {
+		return ErrPermissionDenied
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *authorizationService) ListRoles(ctx context.Context) ([]*entities.Role, error) {
+	return s.roleRepo.List(ctx)
+}
+
+func (s *authorizationService) CreateRole(ctx context.Context, actor *entities.User, name, description string, permissions []string) (*entities.Role, error) {
+	if err := s.validatePermissions(ctx, permissions); err != nil {
+		return nil, err
+	}
+	if err := s.authorizePermissions(ctx, actor, permissions); err != nil {
+		return nil, err
+	}
+	role, err := entities.NewRole(name, description, permissions)
+	if err != nil {
+		return nil, err_domain.Wrap(err_domain.ValidationFailed, err)
+	}
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateRole) {
+			return nil, ErrRoleAlreadyExists
+		}
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *authorizationService) UpdateRole(ctx context.Context, actor *entities.User, name, description string, permissions []string) (*entities.Role, error) {
+	role, err := s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRoleNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, err
+	}
+	if err := s.validatePermissions(ctx, permissions); err != nil {
+		return nil, err
+	}
+	if err := s.authorizePermissions(ctx, actor, append(slices.Clone(role.Permissions), permissions...)); err != nil {
+		return nil, err
+	}
+	previous := role.Permissions
+	role.SetPermissions(permissions)
+	if role.System && !slices.Equal(previous, role.Permissions) {
+		return nil, ErrRoleProtected
+	}
+	if description != "" {
+		role.Description = description
+	}
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *authorizationService) DeleteRole(ctx context.Context, actor *entities.User, name string) error {
+	role, err := s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRoleNotFound) {
+			return ErrRoleNotFound
+		}
+		return err
+	}
+	if role.System {
+		return ErrRoleProtected
+	}
+	if err := s.authorizePermissions(ctx, actor, role.Permissions); err != nil {
+		return err
+	}
+	return s.roleRepo.Delete(ctx, name)
+}
+
+func (s *authorizationService) ListPermissions(ctx context.Context) ([]*entities.Permission, error) {
+	return s.permissionRepo.List(ctx)
+}
+
+// authorizePermissions exige que actor tenga todos los permisos indicados
+func (s *authorizationService) authorizePermissions(ctx context.Context, actor *entities.User, permissions []string) error {
+	actorPermissions, err := s.Permissions(ctx, actor.RoleNames())
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if !slices.Contains(actorPermissions, permission) {
+			return ErrUnauthorizedRoleGrant
+		}
+	}
+	return nil
+}
+
+// validatePermissions exige que los permisos estén registrados para que un error de tipeo no pase inadvertido
+func (s *authorizationService) validatePermissions(ctx context.Context, permissions []string) error {
+	found, err := s.permissionRepo.GetByNames(ctx, permissions)
+	if err != nil {
+		return err
+	}
+	for _, name := range permissions {
+		if !slices.ContainsFunc(found, func(permission *entities.Permission) bool { return permission.Name == name }) {
+			return ErrPermissionNotFound
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+	"poc-auth-svc/internal/domain/valueobjects"
+)
+
+var ErrEmailVerificationTokenInvalid = err_domain.New(err_domain.EmailVerificationTokenInvalid)
+
+type EmailVerificationService interface {
+	// ConfirmEmail marca el email como verificado; el token solo vale para el email que tenía el usuario al emitirlo
+	ConfirmEmail(ctx context.Context, userID, email string) (*entities.User, error)
+	// PendingVerification devuelve el usuario con ese email si todavía no lo verificó, o nil en cualquier otro caso
+	PendingVerification(ctx context.Context, email string) (*entities.User, error)
+}
+
+type emailVerificationService struct {
+	userRepo   repositories.UserRepository
+	emailRules valueobjects.EmailRules
+}
+
+func NewEmailVerificationService(userRepo repositories.UserRepository, emailRules valueobjects.EmailRules) EmailVerificationService {
+	return &emailVerificationService{
+		userRepo:   userRepo,
+		emailRules: emailRules,
+	}
+}
+
+func (s *emailVerificationService) ConfirmEmail(ctx context.Context, userID, email string) (*entities.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user.Email != email {
+		return nil, ErrEmailVerificationTokenInvalid
+	}
+	if user.EmailVerified {
+		return user, nil
+	}
+	user.MarkEmailVerified()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *emailVerificationService) PendingVerification(ctx context.Context, email string) (*entities.User, error) {
+	address, err := valueobjects.NewEmail(email, s.emailRules)
+	if err != nil {
+		return nil, nil
+	}
+	user, err := s.userRepo.GetByEmail(ctx, address)
+	if err != nil || user.EmailVerified || !user.IsActive {
+		return nil, nil
+	}
+	return user, nil
+}
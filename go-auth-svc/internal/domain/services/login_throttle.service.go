@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+)
+
+var ErrAccountLocked = err_domain.New(err_domain.AccountLocked)
+
+// AccountLockedError indica hasta cuándo está bloqueado el login; errors.Is(err, ErrAccountLocked) es true
+type AccountLockedError struct {
+	Until time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return ErrAccountLocked.Error()
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// LockoutPolicy umbrales del bloqueo por fuerza bruta. Al alcanzar el umbral la clave se bloquea
+// por BaseLockDuration y cada fallo adicional duplica el bloqueo hasta MaxLockDuration.
+type LockoutPolicy struct {
+	MaxAccountFailures int
+	MaxIPFailures      int
+	BaseLockDuration   time.Duration
+	MaxLockDuration    time.Duration
+	// FailureWindow tiempo sin fallos después del cual el contador se descarta
+	FailureWindow time.Duration
+}
+
+type LoginThrottleService interface {
+	// Check devuelve *AccountLockedError si la cuenta o la IP de origen están bloqueadas
+	Check(ctx context.Context, email, ip string) error
+	RecordFailure(ctx context.Context, email, ip string) error
+	// RecordSuccess reinicia el contador de la cuenta
+	RecordSuccess(ctx context.Context, email string) error
+}
+
+type loginThrottleService struct {
+	attemptRepo repositories.LoginAttemptRepository
+	policy      LockoutPolicy
+}
+
+func NewLoginThrottleService(attemptRepo repositories.LoginAttemptRepository, policy LockoutPolicy) LoginThrottleService {
+	return &loginThrottleService{
+		attemptRepo: attemptRepo,
+		policy:      policy,
+	}
+}
+
+func (s *loginThrottleService) Check(ctx context.Context, email, ip string) error {
+	now := time.Now()
+	var lockedUntil time.Time
+	for _, key := range throttleKeys(email, ip) {
+		attempt, err := s.attemptRepo.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if attempt != nil && attempt.IsLocked(now) && attempt.LockedUntil.After(lockedUntil) {
+			lockedUntil = *attempt.LockedUntil
+		}
+	}
+	if !lockedUntil.IsZero() {
+		return &AccountLockedError{Until: lockedUntil}
+	}
+	return nil
+}
+
+func (s *loginThrottleService) RecordFailure(ctx context.Context, email, ip string) error {
+	now := time.Now()
+	thresholds := []int{s.policy.MaxAccountFailures, s.policy.MaxIPFailures}
+	for i, key := range throttleKeys(email, ip) {
+		attempt, err := s.attemptRepo.RecordFailure(ctx, key, now, s.policy.FailureWindow)
+		if err != nil {
+			return err
+		}
+		if thresholds[i] > 0 && attempt.Failures >= thresholds[i] {
+			if err := s.attemptRepo.Lock(ctx, key, now.Add(s.lockDuration(attempt.Failures-thresholds[i]))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RecordSuccess no reinicia el contador de la IP: un atacante con una cuenta válida podría
+// intercalar logins correctos para seguir probando contraseñas de otras cuentas desde la misma IP
+func (s *loginThrottleService) RecordSuccess(ctx context.Context, email string) error {
+	return s.attemptRepo.Reset(ctx, accountThrottleKey(email))
+}
+
+// lockDuration backoff exponencial: BaseLockDuration * 2^excess, acotado por MaxLockDuration
+func (s *loginThrottleService) lockDuration(excess int) time.Duration {
+	duration := s.policy.BaseLockDuration
+	for range excess {
+		duration *= 2
+		if duration >= s.policy.MaxLockDuration {
+			return s.policy.MaxLockDuration
+		}
+	}
+	return min(duration, s.policy.MaxLockDuration)
+}
+
+// throttleKeys devuelve la clave de la cuenta y, si se conoce, la de la IP, en ese orden
+func throttleKeys(email, ip string) []string {
+	keys := []string{accountThrottleKey(email)}
+	if ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+// loginAccount identificador de la cuenta en el throttle; el mismo que usa Login a partir del email ingresado
+func loginAccount(user *entities.User) string {
+	if user.EmailCanonical != "" {
+		return user.EmailCanonical
+	}
+	return user.Email
+}
+
+func accountThrottleKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+)
+
+const (
+	recoveryCodeCount = 10
+	// recoveryCodeAlphabet minúsculas y dígitos sin caracteres ambiguos para dictar los códigos
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+var ErrMFAInvalidCode = err_domain.New(err_domain.MFAInvalidCode)
+
+// OTPProvider genera y valida códigos de un solo uso basados en tiempo
+type OTPProvider interface {
+	GenerateSecret() (string, error)
+	ProvisioningURI(secret, accountName string) string
+	// Validate devuelve el paso de tiempo que coincidió con el código
+	Validate(secret, code string, at time.Time) (int64, bool)
+}
+
+// SecretCipher cifra secretos que deben poder recuperarse, a diferencia de las contraseñas
+type SecretCipher interface {
+	Encrypt(plainText string) (string, error)
+	Decrypt(cipherText string) (string, error)
+}
+
+type MFAService interface {
+	// BeginEnrollment genera un secreto TOTP pendiente y devuelve el secreto y su URI otpauth://
+	BeginEnrollment(ctx context.Context, userID string) (string, string, error)
+	// ConfirmEnrollment activa MFA con un primer código válido y devuelve los códigos de recuperación en claro
+	ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error)
+	Disable(ctx context.Context, userID, code string) error
+	// Verify acepta un código TOTP o, en su defecto, un código de recuperación de un solo uso
+	Verify(ctx context.Context, user *entities.User, code, recoveryCode string) error
+	// VerifyLogin es Verify para el segundo paso del login: los códigos incorrectos cuentan como intentos
+	// fallidos de la cuenta y de clientIP en LoginThrottleService, y el contador se reinicia al acertar
+	VerifyLogin(ctx context.Context, user *entities.User, code, recoveryCode, clientIP string) error
+}
+
+type mfaService struct {
+	userRepo repositories.UserRepository
+	hasher   PasswordHasher
+	otp      OTPProvider
+	cipher   SecretCipher
+	throttle LoginThrottleService
+}
+
+func NewMFAService(userRepo repositories.UserRepository, hasher PasswordHasher, otp OTPProvider, cipher SecretCipher, throttle LoginThrottleService) MFAService {
+	return &mfaService{
+		userRepo: userRepo,
+		hasher:   hasher,
+		otp:      otp,
+		cipher:   cipher,
+		throttle: throttle,
+	}
+}
+
+func (s *mfaService) BeginEnrollment(ctx context.Context, userID string) (string, string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	if user.MFAEnabled {
+		return "", "", err_domain.New(err_domain.MFAAlreadyEnabled)
+	}
+	secret, err := s.otp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	encrypted, err := s.cipher.Encrypt(secret)
+	if err != nil {
+		return "", "", err
+	}
+	user.StartMFAEnrollment(encrypted)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return "", "", err
+	}
+	return secret, s.otp.ProvisioningURI(secret, user.Email), nil
+}
+
+func (s *mfaService) ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, err_domain.New(err_domain.MFAAlreadyEnabled)
+	}
+	if user.TOTPPendingSecret == "" {
+		return nil, err_domain.New(err_domain.MFAEnrollmentNotFound)
+	}
+	secret, err := s.cipher.Decrypt(user.TOTPPendingSecret)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := s.otp.Validate(secret, code, time.Now())
+	if !ok {
+		return nil, ErrMFAInvalidCode
+	}
+
+	recoveryCodes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := user.EnableMFA(hashes, step); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+func (s *mfaService) Disable(ctx context.Context, userID, code string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.Verify(ctx, user, code, ""); err != nil {
+		return err
+	}
+	user.DisableMFA()
+	return s.userRepo.Update(ctx, user)
+}
+
+func (s *mfaService) Verify(ctx context.Context, user *entities.User, code, recoveryCode string) error {
+	if !user.MFAEnabled {
+		return err_domain.New(err_domain.MFANotEnabled)
+	}
+	if code != "" {
+		secret, err := s.cipher.Decrypt(user.TOTPSecret)
+		if err != nil {
+			return err
+		}
+		// Un paso ya usado no se acepta de nuevo aunque siga dentro de la ventana
+		step, ok := s.otp.Validate(secret, code, time.Now())
+		if !ok || step <= user.TOTPLastStep {
+			return ErrMFAInvalidCode
+		}
+		user.RecordTOTPStep(step)
+		return s.userRepo.Update(ctx, user)
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(recoveryCode))
+	if normalized != "" {
+		for i, hash := range user.RecoveryCodes {
+			if s.hasher.Compare(hash, normalized) {
+				user.ConsumeRecoveryCode(i)
+				return s.userRepo.Update(ctx, user)
+			}
+		}
+	}
+	return ErrMFAInvalidCode
+}
+
+func (s *mfaService) VerifyLogin(ctx context.Context, user *entities.User, code, recoveryCode, clientIP string) error {
+	account := loginAccount(user)
+	if err := s.throttle.Check(ctx, account, clientIP); err != nil {
+		return err
+	}
+	if err := s.Verify(ctx, user, code, recoveryCode); err != nil {
+		if errors.Is(err, ErrMFAInvalidCode) {
+			if recordErr := s.throttle.RecordFailure(ctx, account, clientIP); recordErr != nil {
+				return recordErr
+			}
+		}
+		return err
+	}
+	return s.throttle.RecordSuccess(ctx, account)
+}
+
+// generateRecoveryCodes genera los códigos en formato xxxxx-xxxxx junto con sus hashes
+func (s *mfaService) generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		var code strings.Builder
+		for i := range 10 {
+			if i == 5 {
+				code.WriteByte('-')
+			}
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeAlphabet))))
+			if err != nil {
+				return nil, nil, err
+			}
+			code.WriteByte(recoveryCodeAlphabet[n.Int64()])
+		}
+		hash, err := s.hasher.Hash(code.String())
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code.String())
+		hashes = append(hashes, hash)
+	}
+	return codes, hashes, nil
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+// Notifier entrega al usuario los tokens que se le envían fuera de banda (email, SMS, etc.)
+type Notifier interface {
+	// SendPasswordReset envía el token de recuperación en claro; el servicio solo guarda su hash
+	SendPasswordReset(ctx context.Context, user *entities.User, token string, expiresAt time.Time) error
+	SendEmailVerification(ctx context.Context, user *entities.User, token string, expiresAt time.Time) error
+}
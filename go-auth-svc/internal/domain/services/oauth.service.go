@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+)
+
+const (
+	CodeChallengeMethodS256 = "S256"
+)
+
+var (
+	ErrInvalidClient            = err_domain.New(err_domain.InvalidClient)
+	ErrInvalidRedirectURI       = err_domain.New(err_domain.InvalidRedirectURI)
+	ErrInvalidScope             = err_domain.New(err_domain.InvalidScope)
+	ErrUnauthorizedGrant        = err_domain.New(err_domain.UnauthorizedGrant)
+	ErrInvalidAuthorizationCode = err_domain.New(err_domain.InvalidAuthorizationCode)
+	ErrInvalidCodeVerifier      = err_domain.New(err_domain.InvalidCodeVerifier)
+)
+
+type OAuthService interface {
+	// RegisterClient registra un cliente y devuelve su secreto en claro, vacío para clientes públicos
+	RegisterClient(ctx context.Context, name string, redirectURIs, grantTypes, scopes []string, public bool) (*entities.Client, string, error)
+	GetClient(ctx context.Context, clientID string) (*entities.Client, error)
+	ListClients(ctx context.Context) ([]*entities.Client, error)
+	// AuthenticateClient valida el secreto de clientes confidenciales; los públicos solo se identifican
+	AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*entities.Client, error)
+	CreateAuthorizationCode(ctx context.Context, client *entities.Client, userID, redirectURI string, scopes []string, nonce, codeChallenge, codeChallengeMethod string) (string, error)
+	ExchangeAuthorizationCode(ctx context.Context, client *entities.Client, code, redirectURI, codeVerifier string) (*entities.AuthorizationCode, error)
+	// GrantClientCredentials resuelve los scopes de un token de service account; sin scopes solicitados concede todos los permitidos
+	GrantClientCredentials(ctx context.Context, client *entities.Client, requestedScopes []string) ([]string, error)
+}
+
+type oauthService struct {
+	clientRepo repositories.ClientRepository
+	codeRepo   repositories.AuthorizationCodeRepository
+	hasher     PasswordHasher
+	codeTTL    time.Duration
+}
+
+func NewOAuthService(clientRepo repositories.ClientRepository, codeRepo repositories.AuthorizationCodeRepository, hasher PasswordHasher, codeTTL time.Duration) OAuthService {
+	return &oauthService{
+		clientRepo: clientRepo,
+		codeRepo:   codeRepo,
+		hasher:     hasher,
+		codeTTL:    codeTTL,
+	}
+}
+
+func (s *oauthService) RegisterClient(ctx context.Context, name string, redirectURIs, grantTypes, scopes []string, public bool) (*entities.Client, string, error) {
+	client, err := entities.NewClient(name, redirectURIs, grantTypes, scopes, public)
+	if err != nil {
+		return nil, "", err_domain.Wrap(err_domain.ValidationFailed, err)
+	}
+	var secret string
+	if !public {
+		if secret, err = generateOpaqueToken(); err != nil {
+			return nil, "", err
+		}
+		if client.SecretHash, err = s.hasher.Hash(secret); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := s.clientRepo.Create(ctx, client); err != nil {
+		return nil, "", err
+	}
+	return client, secret, nil
+}
+
+func (s *oauthService) GetClient(ctx context.Context, clientID string) (*entities.Client, error) {
+	client, err := s.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrClientNotFound) {
+			return nil, ErrInvalidClient
+		}
+		return nil, err
+	}
+	if !client.IsActive {
+		return nil, ErrInvalidClient
+	}
+	return client, nil
+}
+
+func (s *oauthService) ListClients(ctx context.Context) ([]*entities.Client, error) {
+	return s.clientRepo.List(ctx)
+}
+
+func (s *oauthService) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*entities.Client, error) {
+	client, err := s.GetClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client.Public {
+		if clientSecret != "" {
+			return nil, ErrInvalidClient
+		}
+		return client, nil
+	}
+	if clientSecret == "" || !s.hasher.Compare(client.SecretHash, clientSecret) {
+		return nil, ErrInvalidClient
+	}
+	return client, nil
+}
+
+func (s *oauthService) CreateAuthorizationCode(ctx context.Context, client *entities.Client, userID, redirectURI string, scopes []string, nonce, codeChallenge, codeChallengeMethod string) (string, error) {
+	if !client.AllowsGrant(entities.GrantTypeAuthorizationCode) {
+		return "", ErrUnauthorizedGrant
+	}
+	if !client.HasRedirectURI(redirectURI) {
+		return "", ErrInvalidRedirectURI
+	}
+	if !client.AllowsScopes(scopes) {
+		return "", ErrInvalidScope
+	}
+	// PKCE es obligatorio para todos los clientes y solo se acepta S256
+	if codeChallenge == "" || codeChallengeMethod != CodeChallengeMethodS256 {
+		return "", ErrInvalidCodeVerifier
+	}
+
+	plainCode, err := generateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	code := entities.NewAuthorizationCode(HashToken(plainCode), client.ID, userID, redirectURI, scopes, nonce, codeChallenge, codeChallengeMethod, s.codeTTL)
+	if err := s.codeRepo.Create(ctx, code); err != nil {
+		return "", err
+	}
+	return plainCode, nil
+}
+
+func (s *oauthService) ExchangeAuthorizationCode(ctx context.Context, client *entities.Client, plainCode, redirectURI, codeVerifier string) (*entities.AuthorizationCode, error) {
+	code, err := s.codeRepo.Consume(ctx, HashToken(plainCode))
+	if err != nil {
+		if errors.Is(err, repositories.ErrAuthorizationCodeNotFound) {
+			return nil, ErrInvalidAuthorizationCode
+		}
+		return nil, err
+	}
+	if code.IsExpired() || code.ClientID != client.ID || code.RedirectURI != redirectURI {
+		return nil, ErrInvalidAuthorizationCode
+	}
+	if !verifyCodeChallenge(code.CodeChallenge, codeVerifier) {
+		return nil, ErrInvalidCodeVerifier
+	}
+	return code, nil
+}
+
+func (s *oauthService) GrantClientCredentials(ctx context.Context, client *entities.Client, requestedScopes []string) ([]string, error) {
+	if client.Public || !client.IsServiceAccount() {
+		return nil, ErrUnauthorizedGrant
+	}
+	if len(requestedScopes) == 0 {
+		return client.Scopes, nil
+	}
+	if !client.AllowsScopes(requestedScopes) {
+		return nil, ErrInvalidScope
+	}
+	return requestedScopes, nil
+}
+
+// verifyCodeChallenge comprueba PKCE S256: BASE64URL(SHA256(code_verifier)) == code_challenge
+func verifyCodeChallenge(codeChallenge, codeVerifier string) bool {
+	if codeVerifier == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(codeVerifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
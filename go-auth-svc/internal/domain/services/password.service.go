@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+	"poc-auth-svc/internal/domain/valueobjects"
+)
+
+var (
+	ErrPasswordResetTokenInvalid = err_domain.New(err_domain.PasswordResetTokenInvalid)
+	ErrCurrentPasswordInvalid    = err_domain.New(err_domain.CurrentPasswordInvalid)
+)
+
+type PasswordService interface {
+	// RequestReset emite un token de recuperación y lo entrega por el Notifier. Un email desconocido
+	// o un usuario inactivo no devuelven error para no revelar qué cuentas existen.
+	RequestReset(ctx context.Context, email string) error
+	// ResetPassword consume el token y guarda la nueva contraseña; devuelve el usuario actualizado
+	ResetPassword(ctx context.Context, token, newPassword string) (*entities.User, error)
+	// ChangePassword exige la contraseña actual antes de guardar la nueva
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (*entities.User, error)
+	// ForceReset bloquea el login con la contraseña actual y envía al usuario un enlace para restablecerla
+	ForceReset(ctx context.Context, userID string) error
+}
+
+type passwordService struct {
+	userRepo       repositories.UserRepository
+	resetRepo      repositories.PasswordResetTokenRepository
+	hasher         PasswordHasher
+	passwordPolicy PasswordPolicyService
+	notifier       Notifier
+	resetTTL       time.Duration
+	emailRules     valueobjects.EmailRules
+}
+
+func NewPasswordService(userRepo repositories.UserRepository, resetRepo repositories.PasswordResetTokenRepository, hasher PasswordHasher, passwordPolicy PasswordPolicyService, notifier Notifier, resetTTL time.Duration, emailRules valueobjects.EmailRules) PasswordService {
+	return &passwordService{
+		userRepo:       userRepo,
+		resetRepo:      resetRepo,
+		hasher:         hasher,
+		passwordPolicy: passwordPolicy,
+		notifier:       notifier,
+		resetTTL:       resetTTL,
+		emailRules:     emailRules,
+	}
+}
+
+func (s *passwordService) RequestReset(ctx context.Context, email string) error {
+	address, err := valueobjects.NewEmail(email, s.emailRules)
+	if err != nil {
+		return nil
+	}
+	user, err := s.userRepo.GetByEmail(ctx, address)
+	if err != nil || !user.IsActive {
+		return nil
+	}
+	return s.sendResetToken(ctx, user)
+}
+
+func (s *passwordService) ForceReset(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	user.RequirePasswordReset()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return s.sendResetToken(ctx, user)
+}
+
+// sendResetToken reemplaza los tokens pendientes del usuario por uno nuevo y lo entrega por el Notifier
+func (s *passwordService) sendResetToken(ctx context.Context, user *entities.User) error {
+	if err := s.resetRepo.DeleteByUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	plainToken, err := generateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	token := entities.NewPasswordResetToken(user.ID, HashToken(plainToken), s.resetTTL)
+	if err := s.resetRepo.Create(ctx, token); err != nil {
+		return err
+	}
+	return s.notifier.SendPasswordReset(ctx, user, plainToken, token.ExpiresAt)
+}
+
+func (s *passwordService) ResetPassword(ctx context.Context, plainToken, newPassword string) (*entities.User, error) {
+	tokenHash := HashToken(plainToken)
+	token, err := s.resetRepo.GetByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPasswordResetTokenNotFound) {
+			return nil, ErrPasswordResetTokenInvalid
+		}
+		return nil, err
+	}
+	if token.IsExpired() {
+		return nil, ErrPasswordResetTokenInvalid
+	}
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		return nil, ErrPasswordResetTokenInvalid
+	}
+	if !user.IsActive {
+		return nil, err_domain.New(err_domain.UserInactive)
+	}
+	// La política se valida antes de consumir el token para que el usuario pueda reintentar con el mismo enlace
+	if err := s.passwordPolicy.ValidateChange(ctx, user, newPassword); err != nil {
+		return nil, err
+	}
+	if _, err := s.resetRepo.Consume(ctx, tokenHash); err != nil {
+		if errors.Is(err, repositories.ErrPasswordResetTokenNotFound) {
+			return nil, ErrPasswordResetTokenInvalid
+		}
+		return nil, err
+	}
+
+	if err := s.setPassword(ctx, user, newPassword); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *passwordService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (*entities.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.hasher.Compare(user.Password, currentPassword) {
+		return nil, ErrCurrentPasswordInvalid
+	}
+	if err := s.passwordPolicy.ValidateChange(ctx, user, newPassword); err != nil {
+		return nil, err
+	}
+	if err := s.setPassword(ctx, user, newPassword); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// setPassword guarda la nueva contraseña y mueve la actual al historial
+func (s *passwordService) setPassword(ctx context.Context, user *entities.User, newPassword string) error {
+	hashedPassword, err := s.hasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	user.RememberPassword(s.passwordPolicy.HistorySize())
+	if err := user.UpdatePassword(hashedPassword); err != nil {
+		return err
+	}
+	return s.userRepo.Update(ctx, user)
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+)
+
+// commonPasswordsList contraseñas más usadas en filtraciones públicas, una por línea y en minúsculas
+//
+//go:embed common_passwords.txt
+var commonPasswordsList string
+
+var commonPasswords = loadCommonPasswords(commonPasswordsList)
+
+// minEmailLocalPartLength evita rechazar contraseñas por coincidir con partes locales muy cortas como "jo"
+const minEmailLocalPartLength = 3
+
+// ErrPasswordPolicy permite detectar con errors.Is cualquier *PasswordPolicyError
+var ErrPasswordPolicy = err_domain.New(err_domain.ValidationFailed)
+
+// PasswordPolicyError reúne todas las reglas que incumple la contraseña, cada una con su código
+type PasswordPolicyError struct {
+	Violations []err_domain.ErrorCode
+}
+
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, code := range e.Violations {
+		messages = append(messages, err_domain.GetMessage(code))
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordPolicy
+}
+
+// PasswordPolicy reglas configurables para las contraseñas nuevas. Un valor cero desactiva la regla.
+type PasswordPolicy struct {
+	// MinLength se cuenta en caracteres
+	MinLength int
+	// MaxLength se cuenta en bytes UTF-8; con bcrypt no debería superar los 72 bytes que el algoritmo admite
+	MaxLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	// ForbidEmail rechaza contraseñas que contienen la parte local del email del usuario
+	ForbidEmail bool
+	// ForbidCommon rechaza las contraseñas de la lista embebida de contraseñas más comunes
+	ForbidCommon bool
+	// MinEntropyBits entropía mínima estimada según el alfabeto usado y la longitud
+	MinEntropyBits float64
+	// HistorySize cantidad de contraseñas anteriores que no pueden reutilizarse
+	HistorySize int
+}
+
+// BreachedPasswordChecker consulta un corpus de contraseñas filtradas sin enviar la contraseña en claro
+type BreachedPasswordChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+type PasswordPolicyService interface {
+	// Validate comprueba las reglas que no dependen del historial; se usa en el registro
+	Validate(ctx context.Context, password, email string) error
+	// ValidateChange además rechaza la contraseña actual y las del historial del usuario
+	ValidateChange(ctx context.Context, user *entities.User, password string) error
+	// HistorySize cantidad de hashes que deben conservarse en el historial del usuario
+	HistorySize() int
+}
+
+type passwordPolicyService struct {
+	policy   PasswordPolicy
+	hasher   PasswordHasher
+	breached BreachedPasswordChecker
+}
+
+// NewPasswordPolicyService breached es opcional: con nil no se consulta ningún corpus de filtraciones
+func NewPasswordPolicyService(policy PasswordPolicy, hasher PasswordHasher, breached BreachedPasswordChecker) PasswordPolicyService {
+	return &passwordPolicyService{
+		policy:   policy,
+		hasher:   hasher,
+		breached: breached,
+	}
+}
+
+func (s *passwordPolicyService) Validate(ctx context.Context, password, email string) error {
+	violations, err := s.violations(ctx, password, email)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func (s *passwordPolicyService) ValidateChange(ctx context.Context, user *entities.User, password string) error {
+	violations, err := s.violations(ctx, password, user.Email)
+	if err != nil {
+		return err
+	}
+	if s.isReused(user, password) {
+		violations = append(violations, err_domain.PasswordReused)
+	}
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func (s *passwordPolicyService) HistorySize() int {
+	return s.policy.HistorySize
+}
+
+func (s *passwordPolicyService) violations(ctx context.Context, password, email string) ([]err_domain.ErrorCode, error) {
+	var violations []err_domain.ErrorCode
+	if s.policy.MinLength > 0 && utf8.RuneCountInString(password) < s.policy.MinLength {
+		violations = append(violations, err_domain.PasswordTooShort)
+	}
+	// En bytes y no en caracteres: bcrypt trunca a 72 bytes y un carácter no ASCII ocupa varios
+	if s.policy.MaxLength > 0 && len(password) > s.policy.MaxLength {
+		violations = append(violations, err_domain.PasswordTooLong)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+	if s.policy.RequireUppercase && !hasUpper {
+		violations = append(violations, err_domain.PasswordMissingUppercase)
+	}
+	if s.policy.RequireLowercase && !hasLower {
+		violations = append(violations, err_domain.PasswordMissingLowercase)
+	}
+	if s.policy.RequireDigit && !hasDigit {
+		violations = append(violations, err_domain.PasswordMissingDigit)
+	}
+	if s.policy.RequireSymbol && !hasSymbol {
+		violations = append(violations, err_domain.PasswordMissingSymbol)
+	}
+
+	normalized := strings.ToLower(password)
+	if s.policy.ForbidEmail {
+		localPart, _, _ := strings.Cut(strings.ToLower(email), "@")
+		if len(localPart) >= minEmailLocalPartLength && strings.Contains(normalized, localPart) {
+			violations = append(violations, err_domain.PasswordContainsEmail)
+		}
+	}
+	if s.policy.ForbidCommon {
+		if _, ok := commonPasswords[normalized]; ok {
+			violations = append(violations, err_domain.PasswordTooCommon)
+		}
+	}
+	if s.policy.MinEntropyBits > 0 && passwordEntropy(password) < s.policy.MinEntropyBits {
+		violations = append(violations, err_domain.PasswordTooWeak)
+	}
+	// El corpus solo se consulta si las reglas locales pasaron, para no gastar una búsqueda en una contraseña ya rechazada
+	if s.breached != nil && len(violations) == 0 {
+		breached, err := s.breached.IsBreached(ctx, password)
+		if err != nil {
+			return nil, err
+		}
+		if breached {
+			violations = append(violations, err_domain.PasswordBreached)
+		}
+	}
+	return violations, nil
+}
+
+// isReused compara contra la contraseña actual y el historial; el salt de cada hash obliga a probarlos uno por uno
+func (s *passwordPolicyService) isReused(user *entities.User, password string) bool {
+	if s.policy.HistorySize <= 0 {
+		return false
+	}
+	if user.Password != "" && s.hasher.Compare(user.Password, password) {
+		return true
+	}
+	for _, hash := range user.PasswordHistory {
+		if s.hasher.Compare(hash, password) {
+			return true
+		}
+	}
+	return false
+}
+
+// passwordEntropy estima los bits como longitud por log2 del alfabeto usado. Los caracteres repetidos
+// o consecutivos ("aaaa", "1234") cuentan la mitad porque no aportan la misma incertidumbre.
+func passwordEntropy(password string) float64 {
+	var hasUpper, hasLower, hasDigit, hasSymbol, hasOther bool
+	var length float64
+	var previous rune
+	for i, r := range password {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r < utf8.RuneSelf:
+			hasSymbol = true
+		default:
+			hasOther = true
+		}
+		if i > 0 && (r == previous || r == previous+1 || r == previous-1) {
+			length += 0.5
+		} else {
+			length++
+		}
+		previous = r
+	}
+
+	pool := 0
+	if hasUpper {
+		pool += 26
+	}
+	if hasLower {
+		pool += 26
+	}
+	if hasDigit {
+		pool += 10
+	}
+	if hasSymbol {
+		pool += 33
+	}
+	if hasOther {
+		pool += 100
+	}
+	if pool == 0 {
+		return 0
+	}
+	return length * math.Log2(float64(pool))
+}
+
+func loadCommonPasswords(list string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			passwords[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	return passwords
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+)
+
+type RefreshTokenService interface {
+	// Issue emite un refresh token nuevo; si familyID es vacío inicia una nueva familia
+	Issue(ctx context.Context, userID, familyID string) (string, *entities.RefreshToken, error)
+	// Rotate consume el token recibido y emite su reemplazo dentro de la misma familia
+	Rotate(ctx context.Context, plainToken string) (string, *entities.RefreshToken, error)
+	// Lookup devuelve el token sin consumirlo; un token rotado, revocado o expirado devuelve error
+	Lookup(ctx context.Context, plainToken string) (*entities.RefreshToken, error)
+	// RevokeFamily revoca todos los refresh tokens de una sesión
+	RevokeFamily(ctx context.Context, familyID string) error
+}
+
+type refreshTokenService struct {
+	repo repositories.RefreshTokenRepository
+	ttl  time.Duration
+}
+
+func NewRefreshTokenService(repo repositories.RefreshTokenRepository, ttl time.Duration) RefreshTokenService {
+	return &refreshTokenService{
+		repo: repo,
+		ttl:  ttl,
+	}
+}
+
+func (s *refreshTokenService) Issue(ctx context.Context, userID, familyID string) (string, *entities.RefreshToken, error) {
+	plainToken, err := generateOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+	token := entities.NewRefreshToken(userID, familyID, HashToken(plainToken), s.ttl)
+	if err := s.repo.Create(ctx, token); err != nil {
+		return "", nil, err
+	}
+	return plainToken, token, nil
+}
+
+func (s *refreshTokenService) Rotate(ctx context.Context, plainToken string) (string, *entities.RefreshToken, error) {
+	current, err := s.repo.GetByHash(ctx, HashToken(plainToken))
+	if err != nil {
+		if errors.Is(err, repositories.ErrRefreshTokenNotFound) {
+			return "", nil, err_domain.New(err_domain.RefreshTokenInvalid)
+		}
+		return "", nil, err
+	}
+	// Un token ya rotado que vuelve a presentarse indica robo: se revoca toda la familia
+	if current.IsRotated() {
+		if err := s.repo.RevokeFamily(ctx, current.FamilyID); err != nil {
+			return "", nil, err
+		}
+		return "", nil, err_domain.New(err_domain.RefreshTokenReused)
+	}
+	if current.IsRevoked() {
+		return "", nil, err_domain.New(err_domain.RefreshTokenInvalid)
+	}
+	if current.IsExpired() {
+		return "", nil, err_domain.New(err_domain.RefreshTokenExpired)
+	}
+
+	plainNext, err := generateOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+	next := entities.NewRefreshToken(current.UserID, current.FamilyID, HashToken(plainNext), s.ttl)
+
+	// La marca es atómica: si dos peticiones concurrentes presentan el mismo token solo una gana
+	rotated, err := s.repo.MarkRotated(ctx, current.ID, next.ID, time.Now())
+	if err != nil {
+		return "", nil, err
+	}
+	if !rotated {
+		if err := s.repo.RevokeFamily(ctx, current.FamilyID); err != nil {
+			return "", nil, err
+		}
+		return "", nil, err_domain.New(err_domain.RefreshTokenReused)
+	}
+	if err := s.repo.Create(ctx, next); err != nil {
+		return "", nil, err
+	}
+	return plainNext, next, nil
+}
+
+func (s *refreshTokenService) Lookup(ctx context.Context, plainToken string) (*entities.RefreshToken, error) {
+	token, err := s.repo.GetByHash(ctx, HashToken(plainToken))
+	if err != nil {
+		if errors.Is(err, repositories.ErrRefreshTokenNotFound) {
+			return nil, err_domain.New(err_domain.RefreshTokenInvalid)
+		}
+		return nil, err
+	}
+	if token.IsRotated() || token.IsRevoked() {
+		return nil, err_domain.New(err_domain.RefreshTokenInvalid)
+	}
+	if token.IsExpired() {
+		return nil, err_domain.New(err_domain.RefreshTokenExpired)
+	}
+	return token, nil
+}
+
+func (s *refreshTokenService) RevokeFamily(ctx context.Context, familyID string) error {
+	return s.repo.RevokeFamily(ctx, familyID)
+}
+
+// HashToken obtiene el hash SHA-256 con el que se persisten los tokens opacos
+func HashToken(plainToken string) string {
+	sum := sha256.Sum256([]byte(plainToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateOpaqueToken genera 32 bytes aleatorios codificados en base64 url-safe
+func generateOpaqueToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
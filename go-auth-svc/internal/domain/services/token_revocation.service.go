@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"poc-auth-svc/internal/domain/repositories"
+)
+
+type TokenRevocationService interface {
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	// RevokeAllForUser invalida todos los access tokens emitidos hasta ahora y los refresh tokens del usuario
+	RevokeAllForUser(ctx context.Context, userID string) error
+	IsRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error)
+}
+
+type tokenRevocationService struct {
+	revocationRepo repositories.TokenRevocationRepository
+	refreshRepo    repositories.RefreshTokenRepository
+	accessTokenTTL time.Duration
+}
+
+func NewTokenRevocationService(revocationRepo repositories.TokenRevocationRepository, refreshRepo repositories.RefreshTokenRepository, accessTokenTTL time.Duration) TokenRevocationService {
+	return &tokenRevocationService{
+		revocationRepo: revocationRepo,
+		refreshRepo:    refreshRepo,
+		accessTokenTTL: accessTokenTTL,
+	}
+}
+
+func (s *tokenRevocationService) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	if !expiresAt.After(time.Now()) {
+		return nil
+	}
+	return s.revocationRepo.RevokeToken(ctx, jti, expiresAt)
+}
+
+func (s *tokenRevocationService) RevokeAllForUser(ctx context.Context, userID string) error {
+	// iat tiene precisión de segundos: se trunca el corte para que un token emitido justo después,
+	// en el mismo segundo (p. ej. la sesión nueva tras cambiar la contraseña), no quede revocado
+	now := time.Now().Truncate(time.Second)
+	// El corte debe sobrevivir al access token más reciente que pudo emitirse antes de este momento
+	if err := s.revocationRepo.RevokeUser(ctx, userID, now, now.Add(s.accessTokenTTL)); err != nil {
+		return err
+	}
+	return s.refreshRepo.RevokeAllForUser(ctx, userID)
+}
+
+func (s *tokenRevocationService) IsRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error) {
+	return s.revocationRepo.IsRevoked(ctx, jti, userID, issuedAt)
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+)
+
+var (
+	ErrUserNotFound     = err_domain.New(err_domain.UserNotFound)
+	ErrSelfModification = err_domain.New(err_domain.SelfModificationForbidden)
+)
+
+// UserAdminService operaciones de administración sobre cuentas de terceros
+type UserAdminService interface {
+	List(ctx context.Context, filter repositories.UserFilter, page repositories.Page) (*repositories.UserPage, error)
+	Get(ctx context.Context, userID string) (*entities.User, error)
+	// Authorize devuelve el usuario si actorID puede administrarlo: actorID debe ser un usuario activo con
+	// todos los permisos del usuario (AuthorizationService.AuthorizeUserManagement). SetRoles, SetActive y
+	// Delete lo comprueban antes de modificar al usuario.
+	Authorize(ctx context.Context, actorID, userID string) (*entities.User, error)
+	// SetRoles reemplaza los roles del usuario. actorID debe poder otorgar todos los roles
+	// (AuthorizationService.AuthorizeRoleGrant); devuelve ErrRoleNotFound si alguno no existe.
+	SetRoles(ctx context.Context, actorID, userID string, roles []string) (*entities.User, error)
+	SetActive(ctx context.Context, actorID, userID string, active bool) (*entities.User, error)
+	// Delete elimina el usuario junto con sus credenciales WebAuthn y tokens de recuperación pendientes
+	Delete(ctx context.Context, actorID, userID string) error
+}
+
+type userAdminService struct {
+	userRepo       repositories.UserRepository
+	credentialRepo repositories.WebAuthnCredentialRepository
+	resetRepo      repositories.PasswordResetTokenRepository
+	authorization  AuthorizationService
+}
+
+func NewUserAdminService(userRepo repositories.UserRepository, credentialRepo repositories.WebAuthnCredentialRepository, resetRepo repositories.PasswordResetTokenRepository, authorization AuthorizationService) UserAdminService {
+	return &userAdminService{
+		userRepo:       userRepo,
+		credentialRepo: credentialRepo,
+		resetRepo:      resetRepo,
+		authorization:  authorization,
+	}
+}
+
+func (s *userAdminService) List(ctx context.Context, filter repositories.UserFilter, page repositories.Page) (*repositories.UserPage, error) {
+	return s.userRepo.List(ctx, filter, page)
+}
+
+func (s *userAdminService) Get(ctx context.Context, userID string) (*entities.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *userAdminService) Authorize(ctx context.Context, actorID, userID string) (*entities.User, error) {
+	_, user, err := s.authorize(ctx, actorID, userID)
+	return user, err
+}
+
+func (s *userAdminService) SetRoles(ctx context.Context, actorID, userID string, roles []string) (*entities.User, error) {
+	actor, user, err := s.authorize(ctx, actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Se validan los roles efectivos: sin roles el usuario queda con el rol por defecto
+	user.SetRoles(roles)
+	if err := s.authorization.AuthorizeRoleGrant(ctx, actor, user.Roles); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *userAdminService) SetActive(ctx context.Context, actorID, userID string, active bool) (*entities.User, error) {
+	user, err := s.Authorize(ctx, actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		user.Activate()
+	} else {
+		user.Deactivate()
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *userAdminService) Delete(ctx context.Context, actorID, userID string) error {
+	if _, err := s.Authorize(ctx, actorID, userID); err != nil {
+		return err
+	}
+	if err := s.credentialRepo.DeleteByUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.resetRepo.DeleteByUser(ctx, userID); err != nil {
+		return err
+	}
+	return s.userRepo.Delete(ctx, userID)
+}
+
+// authorize carga al administrador y al usuario y comprueba que el primero pueda administrar al segundo
+func (s *userAdminService) authorize(ctx context.Context, actorID, userID string) (*entities.User, *entities.User, error) {
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil || !actor.IsActive {
+		return nil, nil, ErrPermissionDenied
+	}
+	user, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.authorization.AuthorizeUserManagement(ctx, actor, user); err != nil {
+		return nil, nil, err
+	}
+	return actor, user, nil
+}
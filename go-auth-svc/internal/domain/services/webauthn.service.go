@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+	"poc-auth-svc/internal/domain/valueobjects"
+)
+
+var (
+	ErrWebAuthnChallengeInvalid   = err_domain.New(err_domain.WebAuthnChallengeInvalid)
+	ErrWebAuthnVerificationFailed = err_domain.New(err_domain.WebAuthnVerificationFailed)
+	ErrWebAuthnCredentialNotFound = err_domain.New(err_domain.WebAuthnCredentialNotFound)
+	ErrWebAuthnCredentialExists   = err_domain.New(err_domain.WebAuthnCredentialExists)
+	ErrWebAuthnCredentialCloned   = err_domain.New(err_domain.WebAuthnCredentialCloned)
+)
+
+// AttestedCredential credencial extraída de una respuesta de registro ya verificada
+type AttestedCredential struct {
+	CredentialID []byte
+	// PublicKey clave pública COSE tal como viene en authenticatorData
+	PublicKey []byte
+	SignCount uint32
+	AAGUID    []byte
+	Format    string
+}
+
+// WebAuthnVerifier verifica las respuestas del autenticador contra el relying party configurado
+// (WebAuthn nivel 2, secciones 7.1 y 7.2)
+type WebAuthnVerifier interface {
+	VerifyRegistration(clientDataJSON, attestationObject []byte, challenge string) (*AttestedCredential, error)
+	// VerifyAssertion devuelve el contador de firmas informado por el autenticador
+	VerifyAssertion(clientDataJSON, authenticatorData, signature, publicKey []byte, challenge string) (uint32, error)
+}
+
+type WebAuthnService interface {
+	// BeginRegistration crea el desafío de registro y devuelve también las credenciales actuales para excluirlas
+	BeginRegistration(ctx context.Context, userID string) (*entities.WebAuthnSession, []*entities.WebAuthnCredential, error)
+	FinishRegistration(ctx context.Context, userID, name string, clientDataJSON, attestationObject []byte, transports []string) (*entities.WebAuthnCredential, error)
+	// BeginLogin crea el desafío de autenticación; sin email el autenticador elige una passkey residente
+	BeginLogin(ctx context.Context, email string) (*entities.WebAuthnSession, []*entities.WebAuthnCredential, error)
+	// FinishLogin verifica la aserción y devuelve el usuario dueño de la credencial
+	FinishLogin(ctx context.Context, credentialID string, clientDataJSON, authenticatorData, signature, userHandle []byte) (*entities.User, error)
+	ListCredentials(ctx context.Context, userID string) ([]*entities.WebAuthnCredential, error)
+	DeleteCredential(ctx context.Context, userID, id string) error
+}
+
+type webAuthnService struct {
+	userRepo       repositories.UserRepository
+	credentialRepo repositories.WebAuthnCredentialRepository
+	sessionRepo    repositories.WebAuthnSessionRepository
+	verifier       WebAuthnVerifier
+	challengeTTL   time.Duration
+	emailRules     valueobjects.EmailRules
+}
+
+func NewWebAuthnService(userRepo repositories.UserRepository, credentialRepo repositories.WebAuthnCredentialRepository, sessionRepo repositories.WebAuthnSessionRepository, verifier WebAuthnVerifier, challengeTTL time.Duration, emailRules valueobjects.EmailRules) WebAuthnService {
+	return &webAuthnService{
+		userRepo:       userRepo,
+		credentialRepo: credentialRepo,
+		sessionRepo:    sessionRepo,
+		verifier:       verifier,
+		challengeTTL:   challengeTTL,
+		emailRules:     emailRules,
+	}
+}
+
+func (s *webAuthnService) BeginRegistration(ctx context.Context, userID string) (*entities.WebAuthnSession, []*entities.WebAuthnCredential, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, nil, err
+	}
+	credentials, err := s.credentialRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	session, err := s.createSession(ctx, userID, entities.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, credentials, nil
+}
+
+func (s *webAuthnService) FinishRegistration(ctx context.Context, userID, name string, clientDataJSON, attestationObject []byte, transports []string) (*entities.WebAuthnCredential, error) {
+	session, err := s.consumeSession(ctx, clientDataJSON, entities.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, ErrWebAuthnChallengeInvalid
+	}
+	attested, err := s.verifier.VerifyRegistration(clientDataJSON, attestationObject, session.Challenge)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebAuthnVerificationFailed, err)
+	}
+
+	credential := entities.NewWebAuthnCredential(
+		userID,
+		base64.RawURLEncoding.EncodeToString(attested.CredentialID),
+		attested.PublicKey,
+		attested.SignCount,
+		hex.EncodeToString(attested.AAGUID),
+		attested.Format,
+		transports,
+		name,
+	)
+	if err := s.credentialRepo.Create(ctx, credential); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateWebAuthnCredential) {
+			return nil, ErrWebAuthnCredentialExists
+		}
+		return nil, err
+	}
+	return credential, nil
+}
+
+func (s *webAuthnService) BeginLogin(ctx context.Context, email string) (*entities.WebAuthnSession, []*entities.WebAuthnCredential, error) {
+	var userID string
+	credentials := []*entities.WebAuthnCredential{}
+	if email != "" {
+		// Un email desconocido no se informa para no permitir la enumeración de usuarios;
+		// la ceremonia sigue sin credenciales permitidas y fallará al finalizar
+		address, err := valueobjects.NewEmail(email, s.emailRules)
+		var user *entities.User
+		if err == nil {
+			user, err = s.userRepo.GetByEmail(ctx, address)
+		}
+		if err == nil {
+			userID = user.ID
+			if credentials, err = s.credentialRepo.ListByUser(ctx, user.ID); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	session, err := s.createSession(ctx, userID, entities.WebAuthnCeremonyAuthentication)
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, credentials, nil
+}
+
+func (s *webAuthnService) FinishLogin(ctx context.Context, credentialID string, clientDataJSON, authenticatorData, signature, userHandle []byte) (*entities.User, error) {
+	session, err := s.consumeSession(ctx, clientDataJSON, entities.WebAuthnCeremonyAuthentication)
+	if err != nil {
+		return nil, err
+	}
+	credential, err := s.credentialRepo.GetByCredentialID(ctx, credentialID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrWebAuthnCredentialNotFound) {
+			return nil, ErrWebAuthnCredentialNotFound
+		}
+		return nil, err
+	}
+	// La credencial debe pertenecer al usuario del desafío y, si el autenticador lo informa, al user handle
+	if (session.UserID != "" && session.UserID != credential.UserID) ||
+		(len(userHandle) > 0 && string(userHandle) != credential.UserID) {
+		return nil, ErrWebAuthnCredentialNotFound
+	}
+
+	signCount, err := s.verifier.VerifyAssertion(clientDataJSON, authenticatorData, signature, credential.PublicKey, session.Challenge)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebAuthnVerificationFailed, err)
+	}
+	if err := credential.RecordUse(signCount); err != nil {
+		return nil, ErrWebAuthnCredentialCloned
+	}
+	if err := s.credentialRepo.UpdateSignCount(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, credential.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, err_domain.New(err_domain.UserInactive)
+	}
+	return user, nil
+}
+
+func (s *webAuthnService) ListCredentials(ctx context.Context, userID string) ([]*entities.WebAuthnCredential, error) {
+	return s.credentialRepo.ListByUser(ctx, userID)
+}
+
+func (s *webAuthnService) DeleteCredential(ctx context.Context, userID, id string) error {
+	if err := s.credentialRepo.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, repositories.ErrWebAuthnCredentialNotFound) {
+			return ErrWebAuthnCredentialNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *webAuthnService) createSession(ctx context.Context, userID, ceremony string) (*entities.WebAuthnSession, error) {
+	challenge, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	session := entities.NewWebAuthnSession(challenge, userID, ceremony, s.challengeTTL)
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// consumeSession obtiene el desafío desde clientDataJSON y lo consume; el verificador comprueba
+// después que clientDataJSON corresponda exactamente a ese desafío
+func (s *webAuthnService) consumeSession(ctx context.Context, clientDataJSON []byte, ceremony string) (*entities.WebAuthnSession, error) {
+	var clientData struct {
+		Challenge string `json:"challenge"`
+	}
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil || clientData.Challenge == "" {
+		return nil, ErrWebAuthnChallengeInvalid
+	}
+	session, err := s.sessionRepo.Consume(ctx, clientData.Challenge)
+	if err != nil {
+		if errors.Is(err, repositories.ErrWebAuthnSessionNotFound) {
+			return nil, ErrWebAuthnChallengeInvalid
+		}
+		return nil, err
+	}
+	if session.IsExpired() || session.Ceremony != ceremony {
+		return nil, ErrWebAuthnChallengeInvalid
+	}
+	return session, nil
+}
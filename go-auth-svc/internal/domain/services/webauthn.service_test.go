@@ -0,0 +1,299 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+	"poc-auth-svc/internal/domain/services"
+	"poc-auth-svc/internal/domain/valueobjects"
+	"poc-auth-svc/internal/infrastructure/security"
+	"poc-auth-svc/internal/infrastructure/security/webauthntest"
+)
+
+const (
+	testRPID   = "auth.example.com"
+	testOrigin = "https://auth.example.com"
+)
+
+// memoryUserRepository implementa solo las búsquedas que usa el servicio WebAuthn
+type memoryUserRepository struct {
+	repositories.UserRepository
+	users map[string]*entities.User
+}
+
+func (r *memoryUserRepository) GetByID(_ context.Context, id string) (*entities.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, repositories.ErrUserNotFound
+}
+
+func (r *memoryUserRepository) GetByEmail(_ context.Context, email valueobjects.Email) (*entities.User, error) {
+	for _, user := range r.users {
+		if user.EmailCanonical == email.Canonical() {
+			return user, nil
+		}
+	}
+	return nil, repositories.ErrUserNotFound
+}
+
+type memoryCredentialRepository struct {
+	credentials map[string]*entities.WebAuthnCredential
+}
+
+func (r *memoryCredentialRepository) Create(_ context.Context, credential *entities.WebAuthnCredential) error {
+	if _, ok := r.credentials[credential.CredentialID]; ok {
+		return repositories.ErrDuplicateWebAuthnCredential
+	}
+	stored := *credential
+	r.credentials[credential.CredentialID] = &stored
+	return nil
+}
+
+func (r *memoryCredentialRepository) GetByCredentialID(_ context.Context, credentialID string) (*entities.WebAuthnCredential, error) {
+	credential, ok := r.credentials[credentialID]
+	if !ok {
+		return nil, repositories.ErrWebAuthnCredentialNotFound
+	}
+	stored := *credential
+	return &stored, nil
+}
+
+func (r *memoryCredentialRepository) ListByUser(_ context.Context, userID string) ([]*entities.WebAuthnCredential, error) {
+	credentials := []*entities.WebAuthnCredential{}
+	for _, credential := range r.credentials {
+		if credential.UserID == userID {
+			credentials = append(credentials, credential)
+		}
+	}
+	return credentials, nil
+}
+
+func (r *memoryCredentialRepository) UpdateSignCount(_ context.Context, credential *entities.WebAuthnCredential) error {
+	stored, ok := r.credentials[credential.CredentialID]
+	if !ok {
+		return repositories.ErrWebAuthnCredentialNotFound
+	}
+	stored.SignCount = credential.SignCount
+	stored.LastUsedAt = credential.LastUsedAt
+	return nil
+}
+
+func (r *memoryCredentialRepository) Delete(_ context.Context, userID, id string) error {
+	for key, credential := range r.credentials {
+		if credential.UserID == userID && credential.ID == id {
+			delete(r.credentials, key)
+			return nil
+		}
+	}
+	return repositories.ErrWebAuthnCredentialNotFound
+}
+
+func (r *memoryCredentialRepository) DeleteByUser(_ context.Context, userID string) error {
+	for key, credential := range r.credentials {
+		if credential.UserID == userID {
+			delete(r.credentials, key)
+		}
+	}
+	return nil
+}
+
+type memorySessionRepository struct {
+	sessions map[string]*entities.WebAuthnSession
+}
+
+func (r *memorySessionRepository) Create(_ context.Context, session *entities.WebAuthnSession) error {
+	r.sessions[session.Challenge] = session
+	return nil
+}
+
+func (r *memorySessionRepository) Consume(_ context.Context, challenge string) (*entities.WebAuthnSession, error) {
+	session, ok := r.sessions[challenge]
+	if !ok {
+		return nil, repositories.ErrWebAuthnSessionNotFound
+	}
+	delete(r.sessions, challenge)
+	return session, nil
+}
+
+type webAuthnFixture struct {
+	service       services.WebAuthnService
+	user          *entities.User
+	authenticator *webauthntest.Authenticator
+	credentialID  string
+}
+
+// newWebAuthnFixture crea un usuario con una passkey ya registrada por el autenticador de software
+func newWebAuthnFixture(t *testing.T) *webAuthnFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	email, err := valueobjects.NewEmail("ana@example.com", valueobjects.EmailRules{})
+	if err != nil {
+		t.Fatalf("NewEmail: %v", err)
+	}
+	user, err := entities.NewUser(email, "hash", nil)
+	if err != nil {
+		t.Fatalf("NewUser: %v", err)
+	}
+	service := services.NewWebAuthnService(
+		&memoryUserRepository{users: map[string]*entities.User{user.ID: user}},
+		&memoryCredentialRepository{credentials: map[string]*entities.WebAuthnCredential{}},
+		&memorySessionRepository{sessions: map[string]*entities.WebAuthnSession{}},
+		security.NewWebAuthnVerifier(testRPID, []string{testOrigin}, true),
+		time.Minute,
+		valueobjects.EmailRules{},
+	)
+	authenticator, err := webauthntest.NewAuthenticator(testRPID, testOrigin)
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+
+	session, _, err := service.BeginRegistration(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("BeginRegistration: %v", err)
+	}
+	registration, err := authenticator.Register(session.Challenge, webauthntest.AttestationFormatPacked)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	credential, err := service.FinishRegistration(ctx, user.ID, "Laptop", registration.ClientDataJSON, registration.AttestationObject, nil)
+	if err != nil {
+		t.Fatalf("FinishRegistration: %v", err)
+	}
+	if credential.CredentialID != base64.RawURLEncoding.EncodeToString(authenticator.CredentialID) {
+		t.Fatalf("credential id = %q, want the authenticator credential id", credential.CredentialID)
+	}
+
+	return &webAuthnFixture{
+		service:       service,
+		user:          user,
+		authenticator: authenticator,
+		credentialID:  credential.CredentialID,
+	}
+}
+
+// login completa una ceremonia de autenticación con el autenticador del fixture
+func (f *webAuthnFixture) login(t *testing.T, tamper func(*webauthntest.Assertion)) (*entities.User, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	session, credentials, err := f.service.BeginLogin(ctx, f.user.Email)
+	if err != nil {
+		t.Fatalf("BeginLogin: %v", err)
+	}
+	if len(credentials) != 1 {
+		t.Fatalf("BeginLogin returned %d credentials, want 1", len(credentials))
+	}
+	assertion, err := f.authenticator.Assert(session.Challenge)
+	if err != nil {
+		t.Fatalf("Assert: %v", err)
+	}
+	if tamper != nil {
+		tamper(assertion)
+	}
+	return f.service.FinishLogin(ctx, f.credentialID, assertion.ClientDataJSON, assertion.AuthenticatorData, assertion.Signature, []byte(f.user.ID))
+}
+
+func TestWebAuthnRegisterAndLogin(t *testing.T) {
+	fixture := newWebAuthnFixture(t)
+
+	for range 2 {
+		user, err := fixture.login(t, nil)
+		if err != nil {
+			t.Fatalf("FinishLogin: %v", err)
+		}
+		if user.ID != fixture.user.ID {
+			t.Errorf("FinishLogin user = %q, want %q", user.ID, fixture.user.ID)
+		}
+	}
+}
+
+func TestWebAuthnRegistrationRejectsDuplicateCredential(t *testing.T) {
+	fixture := newWebAuthnFixture(t)
+	ctx := context.Background()
+
+	session, _, err := fixture.service.BeginRegistration(ctx, fixture.user.ID)
+	if err != nil {
+		t.Fatalf("BeginRegistration: %v", err)
+	}
+	registration, err := fixture.authenticator.Register(session.Challenge, webauthntest.AttestationFormatNone)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	_, err = fixture.service.FinishRegistration(ctx, fixture.user.ID, "", registration.ClientDataJSON, registration.AttestationObject, nil)
+	if !errors.Is(err, services.ErrWebAuthnCredentialExists) {
+		t.Fatalf("FinishRegistration error = %v, want %v", err, services.ErrWebAuthnCredentialExists)
+	}
+}
+
+func TestWebAuthnLoginRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		before func(*webauthntest.Authenticator)
+		tamper func(*webauthntest.Assertion)
+		want   error
+	}{
+		{
+			name:   "wrong origin",
+			before: func(a *webauthntest.Authenticator) { a.Origin = "https://evil.example.com" },
+			want:   services.ErrWebAuthnVerificationFailed,
+		},
+		{
+			name:   "wrong rpIdHash",
+			before: func(a *webauthntest.Authenticator) { a.RPID = "evil.example.com" },
+			want:   services.ErrWebAuthnVerificationFailed,
+		},
+		{
+			name:   "bad signature",
+			tamper: func(assertion *webauthntest.Assertion) { assertion.Signature[len(assertion.Signature)-1] ^= 0xff },
+			want:   services.ErrWebAuthnVerificationFailed,
+		},
+		{
+			// El autenticador ya firmó con el contador 1; repetirlo indica una credencial clonada
+			name:   "sign count regression",
+			before: func(a *webauthntest.Authenticator) { a.SignCount-- },
+			want:   services.ErrWebAuthnCredentialCloned,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newWebAuthnFixture(t)
+			if _, err := fixture.login(t, nil); err != nil {
+				t.Fatalf("FinishLogin: %v", err)
+			}
+			if tt.before != nil {
+				tt.before(fixture.authenticator)
+			}
+			if _, err := fixture.login(t, tt.tamper); !errors.Is(err, tt.want) {
+				t.Fatalf("FinishLogin error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebAuthnLoginRejectsReusedChallenge(t *testing.T) {
+	fixture := newWebAuthnFixture(t)
+	ctx := context.Background()
+
+	session, _, err := fixture.service.BeginLogin(ctx, fixture.user.Email)
+	if err != nil {
+		t.Fatalf("BeginLogin: %v", err)
+	}
+	assertion, err := fixture.authenticator.Assert(session.Challenge)
+	if err != nil {
+		t.Fatalf("Assert: %v", err)
+	}
+	if _, err := fixture.service.FinishLogin(ctx, fixture.credentialID, assertion.ClientDataJSON, assertion.AuthenticatorData, assertion.Signature, nil); err != nil {
+		t.Fatalf("FinishLogin: %v", err)
+	}
+	_, err = fixture.service.FinishLogin(ctx, fixture.credentialID, assertion.ClientDataJSON, assertion.AuthenticatorData, assertion.Signature, nil)
+	if !errors.Is(err, services.ErrWebAuthnChallengeInvalid) {
+		t.Fatalf("FinishLogin error = %v, want %v", err, services.ErrWebAuthnChallengeInvalid)
+	}
+}
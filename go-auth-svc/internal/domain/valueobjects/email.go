@@ -0,0 +1,153 @@
+package valueobjects
+
+import (
+	"net/mail"
+	"strings"
+
+	err_domain "poc-auth-svc/internal/domain/errors"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	maxEmailLength      = 254
+	maxEmailLocalLength = 64
+	maxDomainLabel      = 63
+)
+
+var ErrInvalidEmail = err_domain.New(err_domain.InvalidEmail)
+
+// EmailRules reglas opcionales de canonicalización. Cambiarlas requiere volver a correr la migración
+// de emails para recalcular la forma canónica de los usuarios existentes.
+type EmailRules struct {
+	// IDN convierte los dominios internacionalizados a punycode (xn--) en la forma canónica
+	IDN bool
+	// ProviderAliases aplica los alias de los proveedores conocidos: Gmail ignora los puntos
+	// y varios proveedores entregan usuario+etiqueta en el buzón de usuario
+	ProviderAliases bool
+}
+
+// emailProvider reglas de alias de un proveedor; Domain es el dominio al que se unifica, si tiene más de uno
+type emailProvider struct {
+	IgnoreDots bool
+	PlusTags   bool
+	Domain     string
+}
+
+var emailProviders = map[string]emailProvider{
+	"gmail.com":      {IgnoreDots: true, PlusTags: true},
+	"googlemail.com": {IgnoreDots: true, PlusTags: true, Domain: "gmail.com"},
+	"outlook.com":    {PlusTags: true},
+	"hotmail.com":    {PlusTags: true},
+	"live.com":       {PlusTags: true},
+	"icloud.com":     {PlusTags: true},
+	"me.com":         {PlusTags: true},
+	"fastmail.com":   {PlusTags: true},
+	"proton.me":      {PlusTags: true},
+	"protonmail.com": {PlusTags: true},
+}
+
+// Email dirección validada en dos formas: la de visualización, tal como la escribió el usuario salvo
+// espacios y mayúsculas del dominio, y la canónica, que identifica la cuenta en búsquedas y unicidad
+type Email struct {
+	display   string
+	canonical string
+}
+
+// NewEmail valida y normaliza la dirección; devuelve ErrInvalidEmail si no es una dirección simple válida
+func NewEmail(raw string, rules EmailRules) (Email, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || len(trimmed) > maxEmailLength {
+		return Email{}, ErrInvalidEmail
+	}
+	// Solo direcciones simples: sin nombre, sin <> y sin comentarios
+	address, err := mail.ParseAddress(trimmed)
+	if err != nil || address.Name != "" || address.Address != trimmed {
+		return Email{}, ErrInvalidEmail
+	}
+	at := strings.LastIndexByte(trimmed, '@')
+	local, domain := trimmed[:at], norm.NFC.String(strings.ToLower(trimmed[at+1:]))
+	if len(local) > maxEmailLocalLength || !validDomain(domain) {
+		return Email{}, ErrInvalidEmail
+	}
+
+	canonicalLocal, canonicalDomain := strings.ToLower(norm.NFC.String(local)), domain
+	if rules.IDN {
+		if canonicalDomain, err = domainToASCII(canonicalDomain); err != nil {
+			return Email{}, ErrInvalidEmail
+		}
+	}
+	if rules.ProviderAliases {
+		canonicalLocal, canonicalDomain = applyProviderAliases(canonicalLocal, canonicalDomain)
+		if canonicalLocal == "" {
+			return Email{}, ErrInvalidEmail
+		}
+	}
+	return Email{
+		display:   local + "@" + domain,
+		canonical: canonicalLocal + "@" + canonicalDomain,
+	}, nil
+}
+
+// Display forma para mostrar y enviar correos
+func (e Email) Display() string {
+	return e.display
+}
+
+// Canonical forma que identifica la cuenta; dos direcciones con la misma forma canónica son el mismo usuario
+func (e Email) Canonical() string {
+	return e.canonical
+}
+
+func (e Email) String() string {
+	return e.display
+}
+
+// IsZero indica que el Email no fue construido con NewEmail
+func (e Email) IsZero() bool {
+	return e.canonical == ""
+}
+
+func validDomain(domain string) bool {
+	if domain == "" || strings.HasPrefix(domain, "[") {
+		return false
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > maxDomainLabel || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+	}
+	return true
+}
+
+// domainToASCII convierte el dominio a su forma ASCII (punycode) con las reglas de IDNA para búsquedas
+func domainToASCII(domain string) (string, error) {
+	ascii, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", err
+	}
+	for _, label := range strings.Split(ascii, ".") {
+		if len(label) > maxDomainLabel {
+			return "", ErrInvalidEmail
+		}
+	}
+	return ascii, nil
+}
+
+func applyProviderAliases(local, domain string) (string, string) {
+	provider, ok := emailProviders[domain]
+	if !ok {
+		return local, domain
+	}
+	if provider.PlusTags {
+		local, _, _ = strings.Cut(local, "+")
+	}
+	if provider.IgnoreDots {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	if provider.Domain != "" {
+		domain = provider.Domain
+	}
+	return local, domain
+}
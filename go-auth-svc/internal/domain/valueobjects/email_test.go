@@ -0,0 +1,91 @@
+package valueobjects
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewEmail(t *testing.T) {
+	idn := EmailRules{IDN: true}
+	aliases := EmailRules{ProviderAliases: true}
+	tests := []struct {
+		name          string
+		raw           string
+		rules         EmailRules
+		wantDisplay   string
+		wantCanonical string
+	}{
+		{name: "lowercases domain only in display", raw: " Ana.Perez@Example.COM ", wantDisplay: "Ana.Perez@example.com", wantCanonical: "ana.perez@example.com"},
+		{name: "idn off keeps unicode domain", raw: "ana@bücher.de", wantDisplay: "ana@bücher.de", wantCanonical: "ana@bücher.de"},
+		{name: "idn on encodes domain", raw: "ana@bücher.de", rules: idn, wantDisplay: "ana@bücher.de", wantCanonical: "ana@xn--bcher-kva.de"},
+		{name: "idn on keeps ascii domain", raw: "ana@example.com", rules: idn, wantDisplay: "ana@example.com", wantCanonical: "ana@example.com"},
+		{name: "idn normalizes decomposed domain", raw: "ana@bu\u0308cher.de", rules: idn, wantDisplay: "ana@bücher.de", wantCanonical: "ana@xn--bcher-kva.de"},
+		{name: "aliases off keep gmail dots and tags", raw: "ana.perez+news@gmail.com", wantDisplay: "ana.perez+news@gmail.com", wantCanonical: "ana.perez+news@gmail.com"},
+		{name: "gmail ignores dots and tags", raw: "Ana.Perez+news@gmail.com", rules: aliases, wantDisplay: "Ana.Perez+news@gmail.com", wantCanonical: "anaperez@gmail.com"},
+		{name: "googlemail maps to gmail", raw: "ana.perez@googlemail.com", rules: aliases, wantDisplay: "ana.perez@googlemail.com", wantCanonical: "anaperez@gmail.com"},
+		{name: "outlook keeps dots", raw: "ana.perez+news@outlook.com", rules: aliases, wantDisplay: "ana.perez+news@outlook.com", wantCanonical: "ana.perez@outlook.com"},
+		{name: "unknown provider keeps tags", raw: "ana+news@example.com", rules: aliases, wantDisplay: "ana+news@example.com", wantCanonical: "ana+news@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, err := NewEmail(tt.raw, tt.rules)
+			if err != nil {
+				t.Fatalf("NewEmail(%q) error = %v", tt.raw, err)
+			}
+			if email.Display() != tt.wantDisplay {
+				t.Errorf("Display() = %q, want %q", email.Display(), tt.wantDisplay)
+			}
+			if email.Canonical() != tt.wantCanonical {
+				t.Errorf("Canonical() = %q, want %q", email.Canonical(), tt.wantCanonical)
+			}
+		})
+	}
+}
+
+func TestNewEmailRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   string
+		rules EmailRules
+	}{
+		{name: "empty", raw: "   "},
+		{name: "missing at", raw: "ana.example.com"},
+		{name: "display name", raw: "Ana <ana@example.com>"},
+		{name: "ip literal", raw: "ana@[127.0.0.1]"},
+		{name: "empty label", raw: "ana@example..com"},
+		{name: "leading hyphen", raw: "ana@-example.com"},
+		{name: "local part too long", raw: strings.Repeat("a", 65) + "@example.com"},
+		{name: "empty local part after stripping tag", raw: "+news@gmail.com", rules: EmailRules{ProviderAliases: true}},
+		{name: "invalid idn domain", raw: "ana@xn--a.com", rules: EmailRules{IDN: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewEmail(tt.raw, tt.rules); !errors.Is(err, ErrInvalidEmail) {
+				t.Fatalf("NewEmail(%q) error = %v, want %v", tt.raw, err, ErrInvalidEmail)
+			}
+		})
+	}
+}
+
+func TestApplyProviderAliases(t *testing.T) {
+	tests := []struct {
+		local, domain         string
+		wantLocal, wantDomain string
+	}{
+		{local: "ana.perez+news", domain: "gmail.com", wantLocal: "anaperez", wantDomain: "gmail.com"},
+		{local: "ana.perez", domain: "googlemail.com", wantLocal: "anaperez", wantDomain: "gmail.com"},
+		{local: "ana+a+b", domain: "icloud.com", wantLocal: "ana", wantDomain: "icloud.com"},
+		{local: "ana.perez+news", domain: "proton.me", wantLocal: "ana.perez", wantDomain: "proton.me"},
+		{local: "+news", domain: "hotmail.com", wantLocal: "", wantDomain: "hotmail.com"},
+		{local: "ana.perez+news", domain: "example.com", wantLocal: "ana.perez+news", wantDomain: "example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.local+"@"+tt.domain, func(t *testing.T) {
+			local, domain := applyProviderAliases(tt.local, tt.domain)
+			if local != tt.wantLocal || domain != tt.wantDomain {
+				t.Errorf("applyProviderAliases(%q, %q) = %q, %q; want %q, %q", tt.local, tt.domain, local, domain, tt.wantLocal, tt.wantDomain)
+			}
+		})
+	}
+}
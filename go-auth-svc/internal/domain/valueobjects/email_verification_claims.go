@@ -0,0 +1,10 @@
+package valueobjects
+
+import "github.com/golang-jwt/jwt"
+
+// EmailVerificationClaims claims del enlace de verificación; Email ata el token al email vigente al emitirlo
+type EmailVerificationClaims struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	jwt.StandardClaims
+}
@@ -0,0 +1,11 @@
+package valueobjects
+
+import "github.com/golang-jwt/jwt"
+
+// IDTokenClaims claims del ID token de OpenID Connect Core 1.0 sección 2
+type IDTokenClaims struct {
+	Nonce    string `json:"nonce,omitempty"`
+	AuthTime int64  `json:"auth_time,omitempty"`
+	Email    string `json:"email,omitempty"`
+	jwt.StandardClaims
+}
@@ -6,5 +6,14 @@ type JWTClaims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	// Roles y Permissions se resuelven al emitir el token; un cambio de roles se refleja en el siguiente token
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	// SessionID identifica la sesión (familia de refresh tokens) a la que pertenece el token
+	SessionID string `json:"sid,omitempty"`
+	// Scope lista de scopes OAuth2 separados por espacio, vacío en tokens del login propio
+	Scope string `json:"scope,omitempty"`
+	// ClientID cliente OAuth2 al que se emitió el token; en tokens de service account UserID va vacío
+	ClientID string `json:"client_id,omitempty"`
 	jwt.StandardClaims
 }
@@ -0,0 +1,9 @@
+package valueobjects
+
+import "github.com/golang-jwt/jwt"
+
+// MFAChallengeClaims claims del token de desafío que emite el login cuando el usuario tiene segundo factor
+type MFAChallengeClaims struct {
+	UserID string `json:"user_id"`
+	jwt.StandardClaims
+}
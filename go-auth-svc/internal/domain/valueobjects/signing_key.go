@@ -0,0 +1,97 @@
+package valueobjects
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// SigningKey clave con la que se firman los JWT; ID se publica como "kid" en el header del token
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+// NewHMACSigningKey crea una clave simétrica HS256 a partir de un secreto compartido
+func NewHMACSigningKey(id string, secret []byte) *SigningKey {
+	return &SigningKey{
+		ID:         id,
+		Algorithm:  AlgorithmHS256,
+		PrivateKey: secret,
+		PublicKey:  secret,
+	}
+}
+
+// NewAsymmetricSigningKey crea una clave RSA, ECDSA P-256 o Ed25519 infiriendo el algoritmo del tipo de clave
+func NewAsymmetricSigningKey(id string, privateKey crypto.PrivateKey) (*SigningKey, error) {
+	key := &SigningKey{ID: id, PrivateKey: privateKey}
+	switch k := privateKey.(type) {
+	case *rsa.PrivateKey:
+		key.Algorithm = AlgorithmRS256
+		key.PublicKey = &k.PublicKey
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, errors.New("only P-256 ECDSA keys are supported")
+		}
+		key.Algorithm = AlgorithmES256
+		key.PublicKey = &k.PublicKey
+	case ed25519.PrivateKey:
+		key.Algorithm = AlgorithmEdDSA
+		key.PublicKey = k.Public()
+	default:
+		return nil, errors.New("unsupported private key type")
+	}
+	return key, nil
+}
+
+// SigningMethod devuelve el método de firma de jwt correspondiente al algoritmo
+func (k *SigningKey) SigningMethod() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+// IsSymmetric indica si la clave es un secreto compartido que no puede publicarse
+func (k *SigningKey) IsSymmetric() bool {
+	return k.Algorithm == AlgorithmHS256
+}
+
+// MarshalPrivateKey serializa la clave privada: el secreto en HS256 y PKCS#8 DER en los demás algoritmos
+func (k *SigningKey) MarshalPrivateKey() ([]byte, error) {
+	if secret, ok := k.PrivateKey.([]byte); ok {
+		return secret, nil
+	}
+	return x509.MarshalPKCS8PrivateKey(k.PrivateKey)
+}
+
+// ParseSigningKey reconstruye una clave serializada con MarshalPrivateKey
+func ParseSigningKey(id, algorithm string, data []byte) (*SigningKey, error) {
+	if algorithm == AlgorithmHS256 {
+		return NewHMACSigningKey(id, data), nil
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+	key, err := NewAsymmetricSigningKey(id, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if key.Algorithm != algorithm {
+		return nil, errors.New("private key does not match the signing algorithm")
+	}
+	return key, nil
+}
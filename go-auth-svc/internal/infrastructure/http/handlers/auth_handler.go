@@ -3,6 +3,8 @@ package handlers
 import (
 	"poc-auth-svc/internal/application/dtos"
 	"poc-auth-svc/internal/application/usecases"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/infrastructure/i18n"
 	"poc-auth-svc/internal/infrastructure/utils"
 
 	"github.com/go-playground/validator"
@@ -27,11 +29,20 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 		return err
 	}
 
+	// Sin preferencia explícita se guarda la del navegador; si tampoco la envía, se decide en cada petición
+	if req.Locale == "" && c.Get(fiber.HeaderAcceptLanguage) != "" {
+		req.Locale = string(utils.Locale(c))
+	}
+	// El token es opcional: solo se usa para autorizar la elección de roles
+	if token, err := utils.ExtractBearerToken(c); err == nil {
+		req.GrantorToken = token
+	}
+
 	response, err := h.authUseCase.Register(c.Context(), &req)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+		return err
 	}
-	return utils.SuccessResponse(c, fiber.StatusCreated, "User registered succesfully", response)
+	return utils.SuccessResponse(c, fiber.StatusCreated, i18n.MsgUserRegistered, response)
 }
 
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
@@ -40,44 +51,114 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return err
 	}
 
+	req.ClientIP = c.IP()
+
 	response, err := h.authUseCase.Login(c.Context(), &req)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusUnauthorized, err.Error(), nil)
+		return err
 	}
-	return utils.SuccessResponse(c, fiber.StatusOK, "Login successful", response)
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgLoginSuccessful, response)
 }
 
 func (h *AuthHandler) ValidateToken(c *fiber.Ctx) error {
 	token, err := utils.ExtractBearerToken(c)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusUnauthorized, err.Error(), nil)
+		return err
 	}
 
 	response, err := h.authUseCase.ValidateToken(c.Context(), token)
 	if err != nil || !response.Valid {
-		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid token", fiber.Map{
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, utils.Translate(c, i18n.MsgInvalidToken), fiber.Map{
 			"valid": false,
 		})
 	}
-	return utils.SuccessResponse(c, fiber.StatusOK, "Token is valid", response)
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgTokenValid, response)
+}
+
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var req dtos.RefreshRequest
+	if err := h.validateAndParseRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.authUseCase.Refresh(c.Context(), &req)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgTokenRefreshed, response)
+}
+
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	token, err := utils.ExtractBearerToken(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.authUseCase.Logout(c.Context(), token); err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgLogoutSuccessful, nil)
+}
+
+func (h *AuthHandler) LogoutAll(c *fiber.Ctx) error {
+	token, err := utils.ExtractBearerToken(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.authUseCase.LogoutAll(c.Context(), token); err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgAllSessionsClosed, nil)
+}
+
+func (h *AuthHandler) UpdateLocale(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+	var req dtos.UpdateLocaleRequest
+	if err := h.validateAndParseRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.authUseCase.UpdateLocale(c.Context(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+	// La respuesta ya sale en el idioma elegido
+	if locale, ok := i18n.ParseLocale(req.Locale); ok {
+		c.Locals(utils.LocalsLocale, locale)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgLocaleUpdated, response)
+}
+
+// JWKS publica las claves públicas de verificación; no usa StandardResponse porque el formato lo define RFC 7517
+func (h *AuthHandler) JWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(h.authUseCase.JWKS(c.Context()))
 }
 
 // validateAndParseRequest función genérica para validar Content-Type, parsear body y validar struct
 func (h *AuthHandler) validateAndParseRequest(c *fiber.Ctx, req interface{}) error {
+	return validateAndParseRequest(c, h.validator, req)
+}
+
+func validateAndParseRequest(c *fiber.Ctx, validate *validator.Validate, req interface{}) error {
 	// Validar Content-Type
 	if err := utils.ValidateContentType(c, "application/json"); err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+		return err
 	}
 
 	// Parsear body
 	if err := c.BodyParser(req); err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", []string{err.Error()})
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, utils.Translate(c, i18n.MsgInvalidRequestBody), []string{err.Error()})
 	}
 
 	// Validar struct
-	if err := h.validator.Struct(req); err != nil {
-		validationErrors := utils.FormatValidationErrors(err)
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Validation failed", validationErrors)
+	if err := validate.Struct(req); err != nil {
+		validationErrors := utils.FormatValidationErrors(utils.Locale(c), err)
+		return utils.ErrorResponseWithCode(c, fiber.StatusBadRequest, string(err_domain.ValidationFailed), utils.Translate(c, i18n.MsgValidationFailed), validationErrors)
 	}
 
 	return nil
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/application/usecases"
+	"poc-auth-svc/internal/domain/services"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type EmailVerificationHandler struct {
+	verificationUseCase usecases.EmailVerificationUseCase
+	validator           *validator.Validate
+}
+
+func NewEmailVerificationHandler(verificationUseCase usecases.EmailVerificationUseCase) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		verificationUseCase: verificationUseCase,
+		validator:           validator.New(),
+	}
+}
+
+func (h *EmailVerificationHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req dtos.VerifyEmailRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	response, err := h.verificationUseCase.VerifyEmail(c.Context(), &req)
+	if err != nil {
+		if errors.Is(err, services.ErrEmailVerificationTokenInvalid) {
+			return utils.DomainErrorResponse(c, fiber.StatusBadRequest, err)
+		}
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgEmailVerified, response)
+}
+
+// ResendVerification responde siempre 202 para no permitir la enumeración de usuarios
+func (h *EmailVerificationHandler) ResendVerification(c *fiber.Ctx) error {
+	var req dtos.ResendVerificationRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	h.verificationUseCase.ResendVerification(c.Context(), &req)
+	return utils.SuccessResponse(c, fiber.StatusAccepted, i18n.MsgEmailVerificationResent, nil)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/services"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// domainErrorStatus estado HTTP de cada código de dominio; los que no figuran son reglas de negocio y responden 400
+var domainErrorStatus = map[err_domain.ErrorCode]int{
+	err_domain.InvalidCredentials:         fiber.StatusUnauthorized,
+	err_domain.CurrentPasswordInvalid:     fiber.StatusUnauthorized,
+	err_domain.RefreshTokenInvalid:        fiber.StatusUnauthorized,
+	err_domain.RefreshTokenExpired:        fiber.StatusUnauthorized,
+	err_domain.RefreshTokenReused:         fiber.StatusUnauthorized,
+	err_domain.MFAInvalidCode:             fiber.StatusUnauthorized,
+	err_domain.MFAChallengeInvalid:        fiber.StatusUnauthorized,
+	err_domain.WebAuthnVerificationFailed: fiber.StatusUnauthorized,
+	err_domain.WebAuthnCredentialCloned:   fiber.StatusUnauthorized,
+	err_domain.InvalidClient:              fiber.StatusUnauthorized,
+	err_domain.AccessTokenInvalid:         fiber.StatusUnauthorized,
+	err_domain.AuthenticationRequired:     fiber.StatusUnauthorized,
+
+	err_domain.UserInactive:              fiber.StatusForbidden,
+	err_domain.EmailNotVerified:          fiber.StatusForbidden,
+	err_domain.PasswordResetRequired:     fiber.StatusForbidden,
+	err_domain.SelfModificationForbidden: fiber.StatusForbidden,
+	err_domain.PermissionDenied:          fiber.StatusForbidden,
+	err_domain.UnauthorizedGrant:         fiber.StatusForbidden,
+	err_domain.UnauthorizedRoleGrant:     fiber.StatusForbidden,
+
+	err_domain.UserNotFound:               fiber.StatusNotFound,
+	err_domain.RoleNotFound:               fiber.StatusNotFound,
+	err_domain.MFAEnrollmentNotFound:      fiber.StatusNotFound,
+	err_domain.WebAuthnCredentialNotFound: fiber.StatusNotFound,
+	err_domain.SigningKeyNotFound:         fiber.StatusNotFound,
+
+	err_domain.UserAlreadyExists:          fiber.StatusConflict,
+	err_domain.RoleAlreadyExists:          fiber.StatusConflict,
+	err_domain.RoleProtected:              fiber.StatusConflict,
+	err_domain.MFAAlreadyEnabled:          fiber.StatusConflict,
+	err_domain.WebAuthnCredentialExists:   fiber.StatusConflict,
+	err_domain.ActiveSigningKeyRetirement: fiber.StatusConflict,
+
+	err_domain.UnsupportedMediaType: fiber.StatusUnsupportedMediaType,
+
+	err_domain.AccountLocked: fiber.StatusLocked,
+
+	err_domain.Internal: fiber.StatusInternalServerError,
+}
+
+// DomainErrorStatus devuelve el estado HTTP que corresponde al código de dominio
+func DomainErrorStatus(code err_domain.ErrorCode) int {
+	if status, ok := domainErrorStatus[code]; ok {
+		return status
+	}
+	return fiber.StatusBadRequest
+}
+
+// ErrorHandler mapeador central de errores, registrado como fiber.Config.ErrorHandler. Los handlers
+// pueden devolver el error del caso de uso tal cual y aquí se traduce a estado HTTP y StandardResponse.
+// Los errores sin código de dominio se registran y se responden como error interno sin exponer el detalle.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return utils.ErrorResponse(c, fiberErr.Code, fiberErr.Message, nil)
+	}
+	var policyErr *services.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return passwordPolicyResponse(c, policyErr)
+	}
+	var lockedErr *services.AccountLockedError
+	if errors.As(err, &lockedErr) {
+		return accountLockedResponse(c, lockedErr)
+	}
+	var domainErr *err_domain.DomainError
+	if !errors.As(err, &domainErr) {
+		domainErr = err_domain.Wrap(err_domain.Internal, err)
+	}
+	status := DomainErrorStatus(domainErr.Code)
+	if status >= fiber.StatusInternalServerError {
+		log.Printf("%s %s failed: %v", c.Method(), c.Path(), err)
+	}
+	return utils.ErrorResponseWithCode(c, status, string(domainErr.Code), i18n.ErrorMessage(utils.Locale(c), domainErr.Code), nil)
+}
+
+// accountLockedResponse responde 423 con Retry-After en segundos hasta el fin del bloqueo
+func accountLockedResponse(c *fiber.Ctx, err *services.AccountLockedError) error {
+	setRetryAfter(c, err.Until)
+	return utils.ErrorResponseWithCode(c, fiber.StatusLocked, string(err_domain.AccountLocked), i18n.ErrorMessage(utils.Locale(c), err_domain.AccountLocked), nil)
+}
+
+// setRetryAfter fija Retry-After en segundos hasta until, como mínimo 1
+func setRetryAfter(c *fiber.Ctx, until time.Time) {
+	retryAfter := int64(math.Ceil(time.Until(until).Seconds()))
+	c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(max(retryAfter, 1), 10))
+}
+
+// passwordPolicyResponse responde 400 con el código y el mensaje de cada regla incumplida
+func passwordPolicyResponse(c *fiber.Ctx, err *services.PasswordPolicyError) error {
+	locale := utils.Locale(c)
+	violations := make([]fiber.Map, 0, len(err.Violations))
+	messages := make([]string, 0, len(err.Violations))
+	for _, code := range err.Violations {
+		message := i18n.ErrorMessage(locale, code)
+		violations = append(violations, fiber.Map{
+			"code":    code,
+			"message": message,
+		})
+		messages = append(messages, message)
+	}
+	return utils.ErrorResponseWithCode(c, fiber.StatusBadRequest, string(err_domain.ValidationFailed), strings.Join(messages, "; "), violations)
+}
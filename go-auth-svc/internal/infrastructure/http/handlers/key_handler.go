@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"poc-auth-svc/internal/application/usecases"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type KeyHandler struct {
+	keyUseCase usecases.KeyUseCase
+}
+
+func NewKeyHandler(keyUseCase usecases.KeyUseCase) *KeyHandler {
+	return &KeyHandler{
+		keyUseCase: keyUseCase,
+	}
+}
+
+func (h *KeyHandler) ListKeys(c *fiber.Ctx) error {
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgSigningKeysRetrieved, h.keyUseCase.ListKeys(c.Context()))
+}
+
+func (h *KeyHandler) RotateKey(c *fiber.Ctx) error {
+	response, err := h.keyUseCase.RotateKey(c.Context())
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusCreated, i18n.MsgSigningKeyRotated, response)
+}
+
+func (h *KeyHandler) RetireKey(c *fiber.Ctx) error {
+	if err := h.keyUseCase.RetireKey(c.Context(), c.Params("kid")); err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgSigningKeyRetired, nil)
+}
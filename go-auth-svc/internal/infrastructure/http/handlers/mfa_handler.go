@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/application/usecases"
+	"poc-auth-svc/internal/infrastructure/http/middleware"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MFAHandler struct {
+	mfaUseCase  usecases.MFAUseCase
+	authUseCase usecases.AuthUseCase
+	validator   *validator.Validate
+}
+
+func NewMFAHandler(mfaUseCase usecases.MFAUseCase, authUseCase usecases.AuthUseCase) *MFAHandler {
+	return &MFAHandler{
+		mfaUseCase:  mfaUseCase,
+		authUseCase: authUseCase,
+		validator:   validator.New(),
+	}
+}
+
+func (h *MFAHandler) Enroll(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+
+	response, err := h.mfaUseCase.Enroll(c.Context(), user.ID)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgMFAEnrollmentStarted, response)
+}
+
+func (h *MFAHandler) Confirm(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+	var req dtos.MFACodeRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	response, err := h.mfaUseCase.Confirm(c.Context(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgMFAEnabled, response)
+}
+
+func (h *MFAHandler) Disable(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+	var req dtos.MFACodeRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	if err := h.mfaUseCase.Disable(c.Context(), user.ID, &req); err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgMFADisabled, nil)
+}
+
+// Verify completa el login de un usuario con segundo factor; no requiere bearer token
+func (h *MFAHandler) Verify(c *fiber.Ctx) error {
+	var req dtos.MFAVerifyRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	req.ClientIP = c.IP()
+
+	// El ErrorHandler responde 423 con Retry-After si los códigos fallidos bloquearon la cuenta
+	response, err := h.authUseCase.VerifyMFA(c.Context(), &req)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgLoginSuccessful, response)
+}
+
+// currentUser devuelve el usuario que dejó RequireAuth; es nil en tokens de service account
+func currentUser(c *fiber.Ctx) (*dtos.UserResponse, bool) {
+	user, ok := c.Locals(middleware.LocalsUser).(*dtos.UserResponse)
+	return user, ok && user != nil
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/application/usecases"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/services"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// loginFormTemplate formulario mínimo de login para el flujo authorization code;
+// reenvía los parámetros de la petición de autorización como campos ocultos
+var loginFormTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Sign in</title></head>
+<body>
+<form method="post" action="/oauth2/authorize">
+{{if .Error}}<p role="alert">{{.Error}}</p>{{end}}
+<input type="hidden" name="response_type" value="{{.Request.ResponseType}}">
+<input type="hidden" name="client_id" value="{{.Request.ClientID}}">
+<input type="hidden" name="redirect_uri" value="{{.Request.RedirectURI}}">
+<input type="hidden" name="scope" value="{{.Request.Scope}}">
+<input type="hidden" name="state" value="{{.Request.State}}">
+<input type="hidden" name="nonce" value="{{.Request.Nonce}}">
+<input type="hidden" name="code_challenge" value="{{.Request.CodeChallenge}}">
+<input type="hidden" name="code_challenge_method" value="{{.Request.CodeChallengeMethod}}">
+<label>Email <input type="email" name="email" value="{{.Request.Email}}" required></label>
+<label>Password <input type="password" name="password" required></label>
+<label>Authentication code <input type="text" name="otp" inputmode="numeric" autocomplete="one-time-code"></label>
+<button type="submit">Sign in</button>
+</form>
+</body>
+</html>`))
+
+type OIDCHandler struct {
+	oidcUseCase usecases.OIDCUseCase
+	validator   *validator.Validate
+}
+
+func NewOIDCHandler(oidcUseCase usecases.OIDCUseCase) *OIDCHandler {
+	return &OIDCHandler{
+		oidcUseCase: oidcUseCase,
+		validator:   validator.New(),
+	}
+}
+
+func (h *OIDCHandler) Discovery(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(h.oidcUseCase.Discovery(c.Context()))
+}
+
+// AuthorizeForm valida la petición de autorización y muestra el formulario de login
+func (h *OIDCHandler) AuthorizeForm(c *fiber.Ctx) error {
+	var req dtos.AuthorizeRequest
+	if err := c.QueryParser(&req); err != nil {
+		return oauthErrorResponse(c, &usecases.OAuthError{Code: usecases.OAuthErrInvalidRequest, Description: err.Error()})
+	}
+	redirectURL, err := h.oidcUseCase.PrepareAuthorize(c.Context(), &req)
+	if err != nil {
+		return oauthErrorResponse(c, err)
+	}
+	if redirectURL != "" {
+		return c.Redirect(redirectURL, fiber.StatusFound)
+	}
+	return renderLoginForm(c, fiber.StatusOK, &req, "")
+}
+
+// Authorize procesa el formulario de login y redirige al cliente con el código de autorización. Los errores
+// de dominio se muestran traducidos en el formulario; el resto los responde el ErrorHandler sin exponer el detalle.
+func (h *OIDCHandler) Authorize(c *fiber.Ctx) error {
+	var req dtos.AuthorizeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return oauthErrorResponse(c, &usecases.OAuthError{Code: usecases.OAuthErrInvalidRequest, Description: err.Error()})
+	}
+	req.ClientIP = c.IP()
+	redirectURL, err := h.oidcUseCase.Authorize(c.Context(), &req)
+	if err != nil {
+		var oauthErr *usecases.OAuthError
+		if errors.As(err, &oauthErr) {
+			return oauthErrorResponse(c, err)
+		}
+		var lockedErr *services.AccountLockedError
+		if errors.As(err, &lockedErr) {
+			setRetryAfter(c, lockedErr.Until)
+		}
+		var domainErr *err_domain.DomainError
+		if !errors.As(err, &domainErr) {
+			return err
+		}
+		status := DomainErrorStatus(domainErr.Code)
+		if status >= fiber.StatusInternalServerError {
+			return err
+		}
+		return renderLoginForm(c, status, &req, i18n.ErrorMessage(utils.Locale(c), domainErr.Code))
+	}
+	return c.Redirect(redirectURL, fiber.StatusFound)
+}
+
+func (h *OIDCHandler) Token(c *fiber.Ctx) error {
+	var req dtos.TokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return oauthErrorResponse(c, &usecases.OAuthError{Code: usecases.OAuthErrInvalidRequest, Description: err.Error()})
+	}
+	if clientID, clientSecret, ok := utils.ExtractBasicCredentials(c); ok {
+		req.ClientID, req.ClientSecret = clientID, clientSecret
+	}
+
+	response, err := h.oidcUseCase.Token(c.Context(), &req)
+	if err != nil {
+		return oauthErrorResponse(c, err)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Set(fiber.HeaderPragma, "no-cache")
+	return c.JSON(response)
+}
+
+func (h *OIDCHandler) UserInfo(c *fiber.Ctx) error {
+	token, err := utils.ExtractBearerToken(c)
+	if err != nil {
+		c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+		return c.Status(fiber.StatusUnauthorized).JSON(dtos.OAuthErrorResponse{Error: usecases.OAuthErrInvalidToken})
+	}
+
+	response, err := h.oidcUseCase.UserInfo(c.Context(), token)
+	if err != nil {
+		c.Set(fiber.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+		return oauthErrorResponse(c, err)
+	}
+	return c.JSON(response)
+}
+
+// Introspect implementa RFC 7662; los tokens inválidos responden 200 con active=false
+func (h *OIDCHandler) Introspect(c *fiber.Ctx) error {
+	var req dtos.IntrospectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return oauthErrorResponse(c, &usecases.OAuthError{Code: usecases.OAuthErrInvalidRequest, Description: err.Error()})
+	}
+	if clientID, clientSecret, ok := utils.ExtractBasicCredentials(c); ok {
+		req.ClientID, req.ClientSecret = clientID, clientSecret
+	}
+
+	response, err := h.oidcUseCase.Introspect(c.Context(), &req)
+	if err != nil {
+		return oauthErrorResponse(c, err)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(response)
+}
+
+func (h *OIDCHandler) RegisterClient(c *fiber.Ctx) error {
+	var req dtos.RegisterClientRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	response, err := h.oidcUseCase.RegisterClient(c.Context(), &req)
+	if err != nil {
+		return utils.DomainErrorResponse(c, fiber.StatusBadRequest, err)
+	}
+	return utils.SuccessResponse(c, fiber.StatusCreated, i18n.MsgClientRegistered, response)
+}
+
+func (h *OIDCHandler) ListClients(c *fiber.Ctx) error {
+	response, err := h.oidcUseCase.ListClients(c.Context())
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgClientsRetrieved, response)
+}
+
+// oauthErrorResponse responde con el formato de error de RFC 6749 en lugar de StandardResponse
+func oauthErrorResponse(c *fiber.Ctx, err error) error {
+	var oauthErr *usecases.OAuthError
+	if !errors.As(err, &oauthErr) {
+		oauthErr = &usecases.OAuthError{Code: usecases.OAuthErrServerError}
+	}
+	status := fiber.StatusBadRequest
+	switch oauthErr.Code {
+	case usecases.OAuthErrInvalidClient, usecases.OAuthErrInvalidToken:
+		status = fiber.StatusUnauthorized
+	case usecases.OAuthErrServerError:
+		status = fiber.StatusInternalServerError
+	}
+	return c.Status(status).JSON(dtos.OAuthErrorResponse{
+		Error:            oauthErr.Code,
+		ErrorDescription: oauthErr.Description,
+	})
+}
+
+func renderLoginForm(c *fiber.Ctx, status int, req *dtos.AuthorizeRequest, message string) error {
+	c.Status(status).Type("html", "utf-8")
+	return loginFormTemplate.Execute(c.Response().BodyWriter(), fiber.Map{
+		"Request": req,
+		"Error":   message,
+	})
+}
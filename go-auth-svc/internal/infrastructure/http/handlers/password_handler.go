@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/application/usecases"
+	"poc-auth-svc/internal/domain/services"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PasswordHandler struct {
+	passwordUseCase usecases.PasswordUseCase
+	validator       *validator.Validate
+}
+
+func NewPasswordHandler(passwordUseCase usecases.PasswordUseCase) *PasswordHandler {
+	return &PasswordHandler{
+		passwordUseCase: passwordUseCase,
+		validator:       validator.New(),
+	}
+}
+
+// ForgotPassword responde siempre 202 exista o no la cuenta, para no permitir la enumeración de usuarios
+func (h *PasswordHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dtos.ForgotPasswordRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	h.passwordUseCase.ForgotPassword(c.Context(), &req)
+	return utils.SuccessResponse(c, fiber.StatusAccepted, i18n.MsgPasswordResetRequested, nil)
+}
+
+func (h *PasswordHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dtos.ResetPasswordRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	if err := h.passwordUseCase.ResetPassword(c.Context(), &req); err != nil {
+		var policyErr *services.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			return passwordPolicyResponse(c, policyErr)
+		}
+		if errors.Is(err, services.ErrPasswordResetTokenInvalid) {
+			return utils.DomainErrorResponse(c, fiber.StatusBadRequest, err)
+		}
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgPasswordReset, nil)
+}
+
+func (h *PasswordHandler) ChangePassword(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+	var req dtos.ChangePasswordRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	response, err := h.passwordUseCase.ChangePassword(c.Context(), user.ID, &req)
+	if err != nil {
+		var policyErr *services.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			return passwordPolicyResponse(c, policyErr)
+		}
+		// 400 y no el 401 del mapeo: el usuario sigue autenticado y el cliente no debe cerrar la sesión
+		if errors.Is(err, services.ErrCurrentPasswordInvalid) {
+			return utils.DomainErrorResponse(c, fiber.StatusBadRequest, err)
+		}
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgPasswordChanged, response)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/application/usecases"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type RoleHandler struct {
+	roleUseCase usecases.RoleUseCase
+	validator   *validator.Validate
+}
+
+func NewRoleHandler(roleUseCase usecases.RoleUseCase) *RoleHandler {
+	return &RoleHandler{
+		roleUseCase: roleUseCase,
+		validator:   validator.New(),
+	}
+}
+
+func (h *RoleHandler) ListRoles(c *fiber.Ctx) error {
+	response, err := h.roleUseCase.ListRoles(c.Context())
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgRolesRetrieved, response)
+}
+
+func (h *RoleHandler) CreateRole(c *fiber.Ctx) error {
+	actor, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+	var req dtos.CreateRoleRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	response, err := h.roleUseCase.CreateRole(c.Context(), actor.ID, &req)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusCreated, i18n.MsgRoleCreated, response)
+}
+
+func (h *RoleHandler) UpdateRole(c *fiber.Ctx) error {
+	actor, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+	var req dtos.UpdateRoleRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	response, err := h.roleUseCase.UpdateRole(c.Context(), actor.ID, c.Params("name"), &req)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgRoleUpdated, response)
+}
+
+func (h *RoleHandler) DeleteRole(c *fiber.Ctx) error {
+	actor, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+	if err := h.roleUseCase.DeleteRole(c.Context(), actor.ID, c.Params("name")); err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgRoleDeleted, nil)
+}
+
+func (h *RoleHandler) ListPermissions(c *fiber.Ctx) error {
+	response, err := h.roleUseCase.ListPermissions(c.Context())
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgPermissionsRetrieved, response)
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/application/usecases"
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/domain/repositories"
+	"poc-auth-svc/internal/domain/services"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type UserAdminHandler struct {
+	userAdminUseCase usecases.UserAdminUseCase
+	validator        *validator.Validate
+}
+
+func NewUserAdminHandler(userAdminUseCase usecases.UserAdminUseCase) *UserAdminHandler {
+	return &UserAdminHandler{
+		userAdminUseCase: userAdminUseCase,
+		validator:        validator.New(),
+	}
+}
+
+func (h *UserAdminHandler) ListUsers(c *fiber.Ctx) error {
+	var req dtos.ListUsersRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, utils.Translate(c, i18n.MsgInvalidQueryParameters), []string{err.Error()})
+	}
+	if err := h.validator.Struct(&req); err != nil {
+		return utils.ErrorResponseWithCode(c, fiber.StatusBadRequest, string(err_domain.ValidationFailed), utils.Translate(c, i18n.MsgValidationFailed), utils.FormatValidationErrors(utils.Locale(c), err))
+	}
+
+	response, err := h.userAdminUseCase.ListUsers(c.Context(), &req)
+	if err != nil {
+		var parseErr *time.ParseError
+		if errors.As(err, &parseErr) {
+			return utils.ErrorResponseWithCode(c, fiber.StatusBadRequest, string(err_domain.ValidationFailed), utils.Translate(c, i18n.MsgInvalidDateFilter), []string{err.Error()})
+		}
+		if errors.Is(err, repositories.ErrInvalidCursor) {
+			return utils.ErrorResponseWithCode(c, fiber.StatusBadRequest, string(err_domain.ValidationFailed), utils.Translate(c, i18n.MsgInvalidCursor), nil)
+		}
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgUsersRetrieved, response)
+}
+
+func (h *UserAdminHandler) GetUser(c *fiber.Ctx) error {
+	response, err := h.userAdminUseCase.GetUser(c.Context(), c.Params("id"))
+	if err != nil {
+		return userAdminErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgUserRetrieved, response)
+}
+
+func (h *UserAdminHandler) UpdateRoles(c *fiber.Ctx) error {
+	actor, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+	var req dtos.UpdateUserRolesRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	response, err := h.userAdminUseCase.UpdateRoles(c.Context(), actor.ID, c.Params("id"), &req)
+	if err != nil {
+		return userAdminErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgUserRolesUpdated, response)
+}
+
+func (h *UserAdminHandler) Activate(c *fiber.Ctx) error {
+	actor, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+
+	response, err := h.userAdminUseCase.Activate(c.Context(), actor.ID, c.Params("id"))
+	if err != nil {
+		return userAdminErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgUserActivated, response)
+}
+
+func (h *UserAdminHandler) Deactivate(c *fiber.Ctx) error {
+	actor, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+
+	response, err := h.userAdminUseCase.Deactivate(c.Context(), actor.ID, c.Params("id"))
+	if err != nil {
+		return userAdminErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgUserDeactivated, response)
+}
+
+func (h *UserAdminHandler) ForcePasswordReset(c *fiber.Ctx) error {
+	actor, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+
+	if err := h.userAdminUseCase.ForcePasswordReset(c.Context(), actor.ID, c.Params("id")); err != nil {
+		return userAdminErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgUserPasswordResetForced, nil)
+}
+
+func (h *UserAdminHandler) DeleteUser(c *fiber.Ctx) error {
+	actor, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+
+	if err := h.userAdminUseCase.DeleteUser(c.Context(), actor.ID, c.Params("id")); err != nil {
+		return userAdminErrorResponse(c, err)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgUserDeleted, nil)
+}
+
+// userAdminErrorResponse los roles inexistentes vienen del cuerpo de la petición y no de la ruta, por eso responden 400
+func userAdminErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrRoleNotFound) {
+		return utils.ErrorResponseWithCode(c, fiber.StatusBadRequest, string(err_domain.RoleNotFound), i18n.ErrorMessage(utils.Locale(c), err_domain.RoleNotFound), nil)
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/application/usecases"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebAuthnHandler struct {
+	webAuthnUseCase usecases.WebAuthnUseCase
+	validator       *validator.Validate
+}
+
+func NewWebAuthnHandler(webAuthnUseCase usecases.WebAuthnUseCase) *WebAuthnHandler {
+	return &WebAuthnHandler{
+		webAuthnUseCase: webAuthnUseCase,
+		validator:       validator.New(),
+	}
+}
+
+func (h *WebAuthnHandler) BeginRegistration(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+
+	response, err := h.webAuthnUseCase.BeginRegistration(c.Context(), user.ID)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgPasskeyRegistrationOpts, response)
+}
+
+func (h *WebAuthnHandler) FinishRegistration(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+	var req dtos.WebAuthnRegisterFinishRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	response, err := h.webAuthnUseCase.FinishRegistration(c.Context(), user.ID, &req)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusCreated, i18n.MsgPasskeyRegistered, response)
+}
+
+func (h *WebAuthnHandler) BeginLogin(c *fiber.Ctx) error {
+	var req dtos.WebAuthnLoginBeginRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	response, err := h.webAuthnUseCase.BeginLogin(c.Context(), &req)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgPasskeyLoginOpts, response)
+}
+
+func (h *WebAuthnHandler) FinishLogin(c *fiber.Ctx) error {
+	var req dtos.WebAuthnLoginFinishRequest
+	if err := validateAndParseRequest(c, h.validator, &req); err != nil {
+		return err
+	}
+
+	response, err := h.webAuthnUseCase.FinishLogin(c.Context(), &req)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgLoginSuccessful, response)
+}
+
+func (h *WebAuthnHandler) ListCredentials(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+
+	response, err := h.webAuthnUseCase.ListCredentials(c.Context(), user.ID)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgPasskeysRetrieved, response)
+}
+
+func (h *WebAuthnHandler) DeleteCredential(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgUserTokenRequired), nil)
+	}
+
+	if err := h.webAuthnUseCase.DeleteCredential(c.Context(), user.ID, c.Params("id")); err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, i18n.MsgPasskeyDeleted, nil)
+}
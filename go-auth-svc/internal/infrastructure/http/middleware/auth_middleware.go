@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"slices"
+
+	"poc-auth-svc/internal/application/dtos"
+	"poc-auth-svc/internal/application/usecases"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// LocalsUser clave de fiber.Ctx.Locals donde se guarda el *dtos.UserResponse autenticado
+	LocalsUser = "user"
+	// LocalsToken clave de fiber.Ctx.Locals donde se guarda el bearer token recibido
+	LocalsToken = "token"
+)
+
+type AuthMiddleware struct {
+	authUseCase usecases.AuthUseCase
+}
+
+func NewAuthMiddleware(authUseCase usecases.AuthUseCase) *AuthMiddleware {
+	return &AuthMiddleware{
+		authUseCase: authUseCase,
+	}
+}
+
+// RequireAuth valida el bearer token y deja el usuario en c.Locals; en tokens de service account el usuario es nil
+func (m *AuthMiddleware) RequireAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, err := utils.ExtractBearerToken(c)
+		if err != nil {
+			return err
+		}
+		response, err := m.authUseCase.ValidateToken(c.Context(), token)
+		if err != nil || !response.Valid {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, utils.Translate(c, i18n.MsgInvalidToken), nil)
+		}
+		c.Locals(LocalsUser, response.User)
+		c.Locals(LocalsToken, token)
+		// La preferencia guardada del usuario tiene prioridad sobre Accept-Language
+		if response.User != nil {
+			if locale, ok := i18n.ParseLocale(response.User.Locale); ok {
+				c.Locals(utils.LocalsLocale, locale)
+			}
+		}
+		return c.Next()
+	}
+}
+
+// RequireRole exige que el usuario autenticado tenga el rol indicado; debe ir después de RequireAuth
+func (m *AuthMiddleware) RequireRole(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals(LocalsUser).(*dtos.UserResponse)
+		if !ok || user == nil || !slices.Contains(user.Roles, role) {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgInsufficientPermissions), nil)
+		}
+		return c.Next()
+	}
+}
+
+// RequirePermission exige que alguno de los roles del usuario otorgue el permiso; debe ir después de RequireAuth
+func (m *AuthMiddleware) RequirePermission(permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals(LocalsUser).(*dtos.UserResponse)
+		if !ok || user == nil || !slices.Contains(user.Permissions, permission) {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgInsufficientPermissions), nil)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Localization negocia el idioma de la respuesta con Accept-Language. RequireAuth lo reemplaza
+// después por el idioma preferido del usuario autenticado, si tiene uno guardado.
+func Localization() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(utils.LocalsLocale, i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage)))
+		c.Vary(fiber.HeaderAcceptLanguage)
+		return c.Next()
+	}
+}
@@ -1,16 +1,75 @@
 package routes
 
 import (
+	"poc-auth-svc/internal/domain/entities"
 	"poc-auth-svc/internal/infrastructure/http/handlers"
+	"poc-auth-svc/internal/infrastructure/http/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupRoutes(app *fiber.App, authHandler *handlers.AuthHandler) {
+func SetupRoutes(app *fiber.App, authMiddleware *middleware.AuthMiddleware, authHandler *handlers.AuthHandler, keyHandler *handlers.KeyHandler, oidcHandler *handlers.OIDCHandler, mfaHandler *handlers.MFAHandler, webAuthnHandler *handlers.WebAuthnHandler, passwordHandler *handlers.PasswordHandler, emailHandler *handlers.EmailVerificationHandler, roleHandler *handlers.RoleHandler, userAdminHandler *handlers.UserAdminHandler) {
+	app.Get("/.well-known/jwks.json", authHandler.JWKS)
+	app.Get("/.well-known/openid-configuration", oidcHandler.Discovery)
+
+	oauth2 := app.Group("/oauth2")
+	oauth2.Get("/authorize", oidcHandler.AuthorizeForm)
+	oauth2.Post("/authorize", oidcHandler.Authorize)
+	oauth2.Post("/token", oidcHandler.Token)
+	oauth2.Post("/introspect", oidcHandler.Introspect)
+	app.Get("/userinfo", oidcHandler.UserInfo)
+	app.Post("/userinfo", oidcHandler.UserInfo)
+
 	api := app.Group("/api/v1")
 
 	auth := api.Group("/auth")
 	auth.Post("/register", authHandler.Register)
 	auth.Post("/login", authHandler.Login)
 	auth.Post("/validate", authHandler.ValidateToken)
+	auth.Post("/refresh", authHandler.Refresh)
+	auth.Post("/logout", authHandler.Logout)
+	auth.Post("/logout-all", authHandler.LogoutAll)
+	auth.Put("/password", authMiddleware.RequireAuth(), passwordHandler.ChangePassword)
+	auth.Put("/locale", authMiddleware.RequireAuth(), authHandler.UpdateLocale)
+	auth.Post("/password/forgot", passwordHandler.ForgotPassword)
+	auth.Post("/password/reset", passwordHandler.ResetPassword)
+	auth.Post("/email/verify", emailHandler.VerifyEmail)
+	auth.Post("/email/resend", emailHandler.ResendVerification)
+
+	mfa := auth.Group("/mfa")
+	mfa.Post("/verify", mfaHandler.Verify)
+	mfa.Post("/enroll", authMiddleware.RequireAuth(), mfaHandler.Enroll)
+	mfa.Post("/confirm", authMiddleware.RequireAuth(), mfaHandler.Confirm)
+	mfa.Post("/disable", authMiddleware.RequireAuth(), mfaHandler.Disable)
+
+	webauthn := auth.Group("/webauthn")
+	webauthn.Post("/login/begin", webAuthnHandler.BeginLogin)
+	webauthn.Post("/login/finish", webAuthnHandler.FinishLogin)
+	webauthn.Post("/register/begin", authMiddleware.RequireAuth(), webAuthnHandler.BeginRegistration)
+	webauthn.Post("/register/finish", authMiddleware.RequireAuth(), webAuthnHandler.FinishRegistration)
+	webauthn.Get("/credentials", authMiddleware.RequireAuth(), webAuthnHandler.ListCredentials)
+	webauthn.Delete("/credentials/:id", authMiddleware.RequireAuth(), webAuthnHandler.DeleteCredential)
+
+	admin := api.Group("/admin", authMiddleware.RequireAuth())
+	keys := admin.Group("/keys", authMiddleware.RequirePermission(entities.PermissionKeysManage))
+	keys.Get("/", keyHandler.ListKeys)
+	keys.Post("/rotate", keyHandler.RotateKey)
+	keys.Delete("/:kid", keyHandler.RetireKey)
+	clients := admin.Group("/clients", authMiddleware.RequirePermission(entities.PermissionClientsManage))
+	clients.Get("/", oidcHandler.ListClients)
+	clients.Post("/", oidcHandler.RegisterClient)
+	roles := admin.Group("/roles", authMiddleware.RequirePermission(entities.PermissionRolesManage))
+	roles.Get("/", roleHandler.ListRoles)
+	roles.Post("/", roleHandler.CreateRole)
+	roles.Put("/:name", roleHandler.UpdateRole)
+	roles.Delete("/:name", roleHandler.DeleteRole)
+	admin.Get("/permissions", authMiddleware.RequirePermission(entities.PermissionRolesManage), roleHandler.ListPermissions)
+	users := admin.Group("/users")
+	users.Get("/", authMiddleware.RequirePermission(entities.PermissionUsersRead), userAdminHandler.ListUsers)
+	users.Get("/:id", authMiddleware.RequirePermission(entities.PermissionUsersRead), userAdminHandler.GetUser)
+	users.Put("/:id/roles", authMiddleware.RequirePermission(entities.PermissionUsersWrite), authMiddleware.RequirePermission(entities.PermissionRolesGrant), userAdminHandler.UpdateRoles)
+	users.Post("/:id/activate", authMiddleware.RequirePermission(entities.PermissionUsersWrite), userAdminHandler.Activate)
+	users.Post("/:id/deactivate", authMiddleware.RequirePermission(entities.PermissionUsersWrite), userAdminHandler.Deactivate)
+	users.Post("/:id/password-reset", authMiddleware.RequirePermission(entities.PermissionUsersWrite), userAdminHandler.ForcePasswordReset)
+	users.Delete("/:id", authMiddleware.RequirePermission(entities.PermissionUsersWrite), userAdminHandler.DeleteUser)
 }
@@ -0,0 +1,46 @@
+package i18n
+
+import (
+	"fmt"
+
+	err_domain "poc-auth-svc/internal/domain/errors"
+)
+
+// MessageID clave de un mensaje de la capa HTTP (respuestas exitosas, validación y errores propios de HTTP)
+type MessageID string
+
+var messageCatalog = map[Locale]map[MessageID]string{
+	Spanish:    spanishMessages,
+	English:    englishMessages,
+	Portuguese: portugueseMessages,
+}
+
+// errorCatalog traducciones de los códigos de dominio. El español no figura porque su fuente es
+// err_domain.GetMessage, que también usan los DomainError para su mensaje por defecto.
+var errorCatalog = map[Locale]map[err_domain.ErrorCode]string{
+	English:    englishErrors,
+	Portuguese: portugueseErrors,
+}
+
+// Message traduce id y le aplica args con fmt. Si falta la traducción se usa la de DefaultLocale
+// y, en último caso, el propio id para que un olvido no deje la respuesta vacía.
+func Message(locale Locale, id MessageID, args ...interface{}) string {
+	template, ok := messageCatalog[locale][id]
+	if !ok {
+		if template, ok = messageCatalog[DefaultLocale][id]; !ok {
+			template = string(id)
+		}
+	}
+	if len(args) == 0 {
+		return template
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// ErrorMessage mensaje del código de dominio en el idioma indicado, con el español como respaldo
+func ErrorMessage(locale Locale, code err_domain.ErrorCode) string {
+	if message, ok := errorCatalog[locale][code]; ok {
+		return message
+	}
+	return err_domain.GetMessage(code)
+}
@@ -0,0 +1,71 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale idioma de las respuestas, como subetiqueta primaria de BCP 47
+type Locale string
+
+const (
+	Spanish    Locale = "es"
+	English    Locale = "en"
+	Portuguese Locale = "pt"
+
+	// DefaultLocale idioma cuando ni el usuario ni Accept-Language indican uno soportado
+	DefaultLocale = Spanish
+)
+
+// SupportedLocales idiomas con catálogo, en orden de preferencia ante empates
+var SupportedLocales = []Locale{Spanish, English, Portuguese}
+
+// ParseLocale acepta etiquetas como "pt-BR" o "EN" y devuelve el idioma soportado que corresponde
+func ParseLocale(tag string) (Locale, bool) {
+	primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	primary, _, _ = strings.Cut(primary, "_")
+	locale := Locale(strings.ToLower(primary))
+	for _, supported := range SupportedLocales {
+		if locale == supported {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate elige el idioma de una cabecera Accept-Language según RFC 9110: gana el de mayor q
+// entre los soportados y, a igual q, el que aparece primero. Sin coincidencias devuelve DefaultLocale.
+func Negotiate(acceptLanguage string) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		if strings.TrimSpace(tag) == "*" {
+			candidates = append(candidates, candidate{DefaultLocale, q})
+			continue
+		}
+		if locale, ok := ParseLocale(tag); ok {
+			candidates = append(candidates, candidate{locale, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLocale
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale
+}
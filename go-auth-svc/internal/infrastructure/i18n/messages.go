@@ -0,0 +1,71 @@
+package i18n
+
+// Mensajes de respuestas exitosas
+const (
+	MsgUserRegistered          MessageID = "user_registered"
+	MsgLoginSuccessful         MessageID = "login_successful"
+	MsgTokenValid              MessageID = "token_valid"
+	MsgTokenRefreshed          MessageID = "token_refreshed"
+	MsgLogoutSuccessful        MessageID = "logout_successful"
+	MsgAllSessionsClosed       MessageID = "all_sessions_closed"
+	MsgLocaleUpdated           MessageID = "locale_updated"
+	MsgPasswordResetRequested  MessageID = "password_reset_requested"
+	MsgPasswordReset           MessageID = "password_reset"
+	MsgPasswordChanged         MessageID = "password_changed"
+	MsgEmailVerified           MessageID = "email_verified"
+	MsgEmailVerificationResent MessageID = "email_verification_resent"
+	MsgMFAEnrollmentStarted    MessageID = "mfa_enrollment_started"
+	MsgMFAEnabled              MessageID = "mfa_enabled"
+	MsgMFADisabled             MessageID = "mfa_disabled"
+	MsgPasskeyRegistrationOpts MessageID = "passkey_registration_options"
+	MsgPasskeyRegistered       MessageID = "passkey_registered"
+	MsgPasskeyLoginOpts        MessageID = "passkey_login_options"
+	MsgPasskeysRetrieved       MessageID = "passkeys_retrieved"
+	MsgPasskeyDeleted          MessageID = "passkey_deleted"
+	MsgClientRegistered        MessageID = "client_registered"
+	MsgClientsRetrieved        MessageID = "clients_retrieved"
+	MsgSigningKeysRetrieved    MessageID = "signing_keys_retrieved"
+	MsgSigningKeyRotated       MessageID = "signing_key_rotated"
+	MsgSigningKeyRetired       MessageID = "signing_key_retired"
+	MsgRolesRetrieved          MessageID = "roles_retrieved"
+	MsgRoleCreated             MessageID = "role_created"
+	MsgRoleUpdated             MessageID = "role_updated"
+	MsgRoleDeleted             MessageID = "role_deleted"
+	MsgPermissionsRetrieved    MessageID = "permissions_retrieved"
+	MsgUsersRetrieved          MessageID = "users_retrieved"
+	MsgUserRetrieved           MessageID = "user_retrieved"
+	MsgUserRolesUpdated        MessageID = "user_roles_updated"
+	MsgUserActivated           MessageID = "user_activated"
+	MsgUserDeactivated         MessageID = "user_deactivated"
+	MsgUserPasswordResetForced MessageID = "user_password_reset_forced"
+	MsgUserDeleted             MessageID = "user_deleted"
+)
+
+// Mensajes de error propios de la capa HTTP
+const (
+	MsgRequestFailed           MessageID = "request_failed"
+	MsgValidationFailed        MessageID = "validation_failed"
+	MsgInvalidRequestBody      MessageID = "invalid_request_body"
+	MsgInvalidQueryParameters  MessageID = "invalid_query_parameters"
+	MsgInvalidDateFilter       MessageID = "invalid_date_filter"
+	MsgInvalidCursor           MessageID = "invalid_cursor"
+	MsgInvalidToken            MessageID = "invalid_token"
+	MsgUserTokenRequired       MessageID = "user_token_required"
+	MsgAuthenticationRequired  MessageID = "authentication_required"
+	MsgInsufficientPermissions MessageID = "insufficient_permissions"
+)
+
+// Plantillas de los errores de validación; reciben el campo y, si la regla tiene, su parámetro
+const (
+	MsgFieldRequired MessageID = "field_required"
+	MsgFieldEmail    MessageID = "field_email"
+	MsgFieldMin      MessageID = "field_min"
+	MsgFieldMax      MessageID = "field_max"
+	MsgFieldLen      MessageID = "field_len"
+	MsgFieldGt       MessageID = "field_gt"
+	MsgFieldGte      MessageID = "field_gte"
+	MsgFieldLt       MessageID = "field_lt"
+	MsgFieldLte      MessageID = "field_lte"
+	MsgFieldOneOf    MessageID = "field_oneof"
+	MsgFieldInvalid  MessageID = "field_invalid"
+)
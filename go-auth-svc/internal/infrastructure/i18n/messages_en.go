@@ -0,0 +1,133 @@
+package i18n
+
+import err_domain "poc-auth-svc/internal/domain/errors"
+
+var englishMessages = map[MessageID]string{
+	MsgUserRegistered:          "User registered successfully",
+	MsgLoginSuccessful:         "Login successful",
+	MsgTokenValid:              "Token is valid",
+	MsgTokenRefreshed:          "Token refreshed successfully",
+	MsgLogoutSuccessful:        "Logout successful",
+	MsgAllSessionsClosed:       "All sessions closed successfully",
+	MsgLocaleUpdated:           "Language updated successfully",
+	MsgPasswordResetRequested:  "If the account exists, a reset link has been sent",
+	MsgPasswordReset:           "Password reset successfully",
+	MsgPasswordChanged:         "Password changed successfully",
+	MsgEmailVerified:           "Email verified successfully",
+	MsgEmailVerificationResent: "If the account is pending verification, a new link has been sent",
+	MsgMFAEnrollmentStarted:    "MFA enrollment started",
+	MsgMFAEnabled:              "MFA enabled successfully",
+	MsgMFADisabled:             "MFA disabled successfully",
+	MsgPasskeyRegistrationOpts: "Registration options created",
+	MsgPasskeyRegistered:       "Passkey registered successfully",
+	MsgPasskeyLoginOpts:        "Login options created",
+	MsgPasskeysRetrieved:       "Passkeys retrieved successfully",
+	MsgPasskeyDeleted:          "Passkey deleted successfully",
+	MsgClientRegistered:        "Client registered successfully",
+	MsgClientsRetrieved:        "Clients retrieved successfully",
+	MsgSigningKeysRetrieved:    "Signing keys retrieved successfully",
+	MsgSigningKeyRotated:       "Signing key rotated successfully",
+	MsgSigningKeyRetired:       "Signing key retired successfully",
+	MsgRolesRetrieved:          "Roles retrieved successfully",
+	MsgRoleCreated:             "Role created successfully",
+	MsgRoleUpdated:             "Role updated successfully",
+	MsgRoleDeleted:             "Role deleted successfully",
+	MsgPermissionsRetrieved:    "Permissions retrieved successfully",
+	MsgUsersRetrieved:          "Users retrieved successfully",
+	MsgUserRetrieved:           "User retrieved successfully",
+	MsgUserRolesUpdated:        "User roles updated successfully",
+	MsgUserActivated:           "User activated successfully",
+	MsgUserDeactivated:         "User deactivated successfully",
+	MsgUserPasswordResetForced: "Password reset required and reset link sent",
+	MsgUserDeleted:             "User deleted successfully",
+
+	MsgRequestFailed:           "Request failed",
+	MsgValidationFailed:        "Validation failed",
+	MsgInvalidRequestBody:      "Invalid request body",
+	MsgInvalidQueryParameters:  "Invalid query parameters",
+	MsgInvalidDateFilter:       "Invalid date filter, expected RFC 3339",
+	MsgInvalidCursor:           "Invalid pagination cursor",
+	MsgInvalidToken:            "Invalid token",
+	MsgUserTokenRequired:       "User token required",
+	MsgAuthenticationRequired:  "Authentication required",
+	MsgInsufficientPermissions: "Insufficient permissions",
+
+	MsgFieldRequired: "%s is required",
+	MsgFieldEmail:    "%s must be a valid email address",
+	MsgFieldMin:      "%s must be at least %s characters long",
+	MsgFieldMax:      "%s must be at most %s characters long",
+	MsgFieldLen:      "%s must be exactly %s characters long",
+	MsgFieldGt:       "%s must be greater than %s",
+	MsgFieldGte:      "%s must be greater than or equal to %s",
+	MsgFieldLt:       "%s must be less than %s",
+	MsgFieldLte:      "%s must be less than or equal to %s",
+	MsgFieldOneOf:    "%s must be one of: %s",
+	MsgFieldInvalid:  "%s is invalid",
+}
+
+var englishErrors = map[err_domain.ErrorCode]string{
+	err_domain.UserNotFound:              "User not found",
+	err_domain.UserAlreadyExists:         "User already exists",
+	err_domain.UserInactive:              "User is inactive",
+	err_domain.EmailNotVerified:          "User email is not verified",
+	err_domain.AccountLocked:             "Too many failed attempts, the account is temporarily locked",
+	err_domain.PasswordResetRequired:     "You must reset your password before signing in",
+	err_domain.SelfModificationForbidden: "You cannot deactivate, delete or change the roles of your own account",
+	err_domain.InvalidEmail:              "Invalid email address",
+	err_domain.ValidationFailed:          "Validation failed",
+	err_domain.UnsupportedMediaType:      "Unsupported request Content-Type",
+	err_domain.InvalidCredentials:        "Invalid credentials",
+	err_domain.Internal:                  "Internal server error",
+
+	err_domain.RoleNotFound:          "Role not found",
+	err_domain.RoleAlreadyExists:     "Role already exists",
+	err_domain.RoleProtected:         "System roles cannot be deleted or have their permissions changed",
+	err_domain.PermissionNotFound:    "Permission not found",
+	err_domain.PermissionDenied:      "You are not allowed to perform this action",
+	err_domain.UnauthorizedRoleGrant: "You cannot grant roles with permissions you do not have",
+
+	err_domain.EmailVerificationTokenInvalid: "The email verification link is invalid or has expired",
+
+	err_domain.RefreshTokenInvalid:    "Invalid refresh token",
+	err_domain.RefreshTokenExpired:    "Refresh token expired",
+	err_domain.RefreshTokenReused:     "Refresh token reused, the session was revoked",
+	err_domain.AccessTokenInvalid:     "Invalid token",
+	err_domain.AuthenticationRequired: "Authentication required",
+
+	err_domain.SigningKeyNotFound:         "Signing key not found",
+	err_domain.ActiveSigningKeyRetirement: "The active signing key cannot be retired without rotating first",
+
+	err_domain.PasswordResetTokenInvalid: "The password reset token is invalid or has expired",
+	err_domain.CurrentPasswordInvalid:    "The current password is incorrect",
+
+	err_domain.PasswordTooShort:         "The password is too short",
+	err_domain.PasswordTooLong:          "The password is too long",
+	err_domain.PasswordMissingUppercase: "The password must include an uppercase letter",
+	err_domain.PasswordMissingLowercase: "The password must include a lowercase letter",
+	err_domain.PasswordMissingDigit:     "The password must include a digit",
+	err_domain.PasswordMissingSymbol:    "The password must include a symbol",
+	err_domain.PasswordContainsEmail:    "The password cannot contain the email",
+	err_domain.PasswordTooCommon:        "The password is too common",
+	err_domain.PasswordBreached:         "The password appears in known data breaches",
+	err_domain.PasswordTooWeak:          "The password is too easy to guess",
+	err_domain.PasswordReused:           "The password was used recently",
+
+	err_domain.MFAAlreadyEnabled:     "Two-factor authentication is already enabled",
+	err_domain.MFANotEnabled:         "Two-factor authentication is not enabled",
+	err_domain.MFAEnrollmentNotFound: "There is no pending two-factor enrollment",
+	err_domain.MFAInvalidCode:        "Invalid verification code",
+	err_domain.MFAChallengeInvalid:   "The two-factor challenge is invalid or has expired",
+
+	err_domain.WebAuthnChallengeInvalid:   "The WebAuthn challenge is invalid or has expired",
+	err_domain.WebAuthnVerificationFailed: "The authenticator response could not be verified",
+	err_domain.WebAuthnCredentialNotFound: "WebAuthn credential not found",
+	err_domain.WebAuthnCredentialExists:   "The WebAuthn credential is already registered",
+	err_domain.WebAuthnCredentialCloned:   "The credential signature counter went backwards, possible cloned authenticator",
+
+	err_domain.InvalidClient:            "Invalid or unauthenticated client",
+	err_domain.InvalidRedirectURI:       "The redirect_uri is not registered for the client",
+	err_domain.InvalidScope:             "Scope not allowed for the client",
+	err_domain.UnauthorizedGrant:        "The client is not allowed to use this grant type",
+	err_domain.InvalidAuthorizationCode: "Invalid or expired authorization code",
+	err_domain.InvalidCodeVerifier:      "The code_verifier does not match the code_challenge",
+}
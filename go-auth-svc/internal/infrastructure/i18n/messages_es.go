@@ -0,0 +1,64 @@
+package i18n
+
+var spanishMessages = map[MessageID]string{
+	MsgUserRegistered:          "Usuario registrado correctamente",
+	MsgLoginSuccessful:         "Inicio de sesion correcto",
+	MsgTokenValid:              "El token es valido",
+	MsgTokenRefreshed:          "Token renovado correctamente",
+	MsgLogoutSuccessful:        "Sesion cerrada correctamente",
+	MsgAllSessionsClosed:       "Todas las sesiones fueron cerradas",
+	MsgLocaleUpdated:           "Idioma actualizado correctamente",
+	MsgPasswordResetRequested:  "Si la cuenta existe, se envio un enlace de recuperacion",
+	MsgPasswordReset:           "Contraseña restablecida correctamente",
+	MsgPasswordChanged:         "Contraseña cambiada correctamente",
+	MsgEmailVerified:           "Email verificado correctamente",
+	MsgEmailVerificationResent: "Si la cuenta esta pendiente de verificacion, se envio un nuevo enlace",
+	MsgMFAEnrollmentStarted:    "Registro del segundo factor iniciado",
+	MsgMFAEnabled:              "Segundo factor habilitado correctamente",
+	MsgMFADisabled:             "Segundo factor deshabilitado correctamente",
+	MsgPasskeyRegistrationOpts: "Opciones de registro creadas",
+	MsgPasskeyRegistered:       "Passkey registrada correctamente",
+	MsgPasskeyLoginOpts:        "Opciones de inicio de sesion creadas",
+	MsgPasskeysRetrieved:       "Passkeys obtenidas correctamente",
+	MsgPasskeyDeleted:          "Passkey eliminada correctamente",
+	MsgClientRegistered:        "Cliente registrado correctamente",
+	MsgClientsRetrieved:        "Clientes obtenidos correctamente",
+	MsgSigningKeysRetrieved:    "Claves de firma obtenidas correctamente",
+	MsgSigningKeyRotated:       "Clave de firma rotada correctamente",
+	MsgSigningKeyRetired:       "Clave de firma retirada correctamente",
+	MsgRolesRetrieved:          "Roles obtenidos correctamente",
+	MsgRoleCreated:             "Rol creado correctamente",
+	MsgRoleUpdated:             "Rol actualizado correctamente",
+	MsgRoleDeleted:             "Rol eliminado correctamente",
+	MsgPermissionsRetrieved:    "Permisos obtenidos correctamente",
+	MsgUsersRetrieved:          "Usuarios obtenidos correctamente",
+	MsgUserRetrieved:           "Usuario obtenido correctamente",
+	MsgUserRolesUpdated:        "Roles del usuario actualizados correctamente",
+	MsgUserActivated:           "Usuario activado correctamente",
+	MsgUserDeactivated:         "Usuario desactivado correctamente",
+	MsgUserPasswordResetForced: "Se exigio el cambio de contraseña y se envio el enlace de recuperacion",
+	MsgUserDeleted:             "Usuario eliminado correctamente",
+
+	MsgRequestFailed:           "La solicitud fallo",
+	MsgValidationFailed:        "Fallo la validacion de datos",
+	MsgInvalidRequestBody:      "El cuerpo de la solicitud es invalido",
+	MsgInvalidQueryParameters:  "Los parametros de consulta son invalidos",
+	MsgInvalidDateFilter:       "Filtro de fecha invalido, se espera RFC 3339",
+	MsgInvalidCursor:           "Cursor de paginacion invalido",
+	MsgInvalidToken:            "Token invalido",
+	MsgUserTokenRequired:       "Se requiere un token de usuario",
+	MsgAuthenticationRequired:  "Se requiere autenticacion",
+	MsgInsufficientPermissions: "Permisos insuficientes",
+
+	MsgFieldRequired: "%s es obligatorio",
+	MsgFieldEmail:    "%s debe ser un email valido",
+	MsgFieldMin:      "%s debe tener al menos %s caracteres",
+	MsgFieldMax:      "%s debe tener como maximo %s caracteres",
+	MsgFieldLen:      "%s debe tener exactamente %s caracteres",
+	MsgFieldGt:       "%s debe ser mayor que %s",
+	MsgFieldGte:      "%s debe ser mayor o igual que %s",
+	MsgFieldLt:       "%s debe ser menor que %s",
+	MsgFieldLte:      "%s debe ser menor o igual que %s",
+	MsgFieldOneOf:    "%s debe ser uno de: %s",
+	MsgFieldInvalid:  "%s es invalido",
+}
@@ -0,0 +1,133 @@
+package i18n
+
+import err_domain "poc-auth-svc/internal/domain/errors"
+
+var portugueseMessages = map[MessageID]string{
+	MsgUserRegistered:          "Usuario registrado com sucesso",
+	MsgLoginSuccessful:         "Login realizado com sucesso",
+	MsgTokenValid:              "O token e valido",
+	MsgTokenRefreshed:          "Token renovado com sucesso",
+	MsgLogoutSuccessful:        "Sessao encerrada com sucesso",
+	MsgAllSessionsClosed:       "Todas as sessoes foram encerradas",
+	MsgLocaleUpdated:           "Idioma atualizado com sucesso",
+	MsgPasswordResetRequested:  "Se a conta existir, um link de recuperacao foi enviado",
+	MsgPasswordReset:           "Senha redefinida com sucesso",
+	MsgPasswordChanged:         "Senha alterada com sucesso",
+	MsgEmailVerified:           "Email verificado com sucesso",
+	MsgEmailVerificationResent: "Se a conta estiver pendente de verificacao, um novo link foi enviado",
+	MsgMFAEnrollmentStarted:    "Cadastro do segundo fator iniciado",
+	MsgMFAEnabled:              "Segundo fator habilitado com sucesso",
+	MsgMFADisabled:             "Segundo fator desabilitado com sucesso",
+	MsgPasskeyRegistrationOpts: "Opcoes de registro criadas",
+	MsgPasskeyRegistered:       "Passkey registrada com sucesso",
+	MsgPasskeyLoginOpts:        "Opcoes de login criadas",
+	MsgPasskeysRetrieved:       "Passkeys obtidas com sucesso",
+	MsgPasskeyDeleted:          "Passkey removida com sucesso",
+	MsgClientRegistered:        "Cliente registrado com sucesso",
+	MsgClientsRetrieved:        "Clientes obtidos com sucesso",
+	MsgSigningKeysRetrieved:    "Chaves de assinatura obtidas com sucesso",
+	MsgSigningKeyRotated:       "Chave de assinatura rotacionada com sucesso",
+	MsgSigningKeyRetired:       "Chave de assinatura retirada com sucesso",
+	MsgRolesRetrieved:          "Papeis obtidos com sucesso",
+	MsgRoleCreated:             "Papel criado com sucesso",
+	MsgRoleUpdated:             "Papel atualizado com sucesso",
+	MsgRoleDeleted:             "Papel removido com sucesso",
+	MsgPermissionsRetrieved:    "Permissoes obtidas com sucesso",
+	MsgUsersRetrieved:          "Usuarios obtidos com sucesso",
+	MsgUserRetrieved:           "Usuario obtido com sucesso",
+	MsgUserRolesUpdated:        "Papeis do usuario atualizados com sucesso",
+	MsgUserActivated:           "Usuario ativado com sucesso",
+	MsgUserDeactivated:         "Usuario desativado com sucesso",
+	MsgUserPasswordResetForced: "A troca de senha foi exigida e o link de recuperacao foi enviado",
+	MsgUserDeleted:             "Usuario removido com sucesso",
+
+	MsgRequestFailed:           "A solicitacao falhou",
+	MsgValidationFailed:        "Falha na validacao dos dados",
+	MsgInvalidRequestBody:      "Corpo da solicitacao invalido",
+	MsgInvalidQueryParameters:  "Parametros de consulta invalidos",
+	MsgInvalidDateFilter:       "Filtro de data invalido, esperado RFC 3339",
+	MsgInvalidCursor:           "Cursor de paginacao invalido",
+	MsgInvalidToken:            "Token invalido",
+	MsgUserTokenRequired:       "E necessario um token de usuario",
+	MsgAuthenticationRequired:  "Autenticacao necessaria",
+	MsgInsufficientPermissions: "Permissoes insuficientes",
+
+	MsgFieldRequired: "%s e obrigatorio",
+	MsgFieldEmail:    "%s deve ser um email valido",
+	MsgFieldMin:      "%s deve ter pelo menos %s caracteres",
+	MsgFieldMax:      "%s deve ter no maximo %s caracteres",
+	MsgFieldLen:      "%s deve ter exatamente %s caracteres",
+	MsgFieldGt:       "%s deve ser maior que %s",
+	MsgFieldGte:      "%s deve ser maior ou igual a %s",
+	MsgFieldLt:       "%s deve ser menor que %s",
+	MsgFieldLte:      "%s deve ser menor ou igual a %s",
+	MsgFieldOneOf:    "%s deve ser um de: %s",
+	MsgFieldInvalid:  "%s e invalido",
+}
+
+var portugueseErrors = map[err_domain.ErrorCode]string{
+	err_domain.UserNotFound:              "Usuario nao encontrado",
+	err_domain.UserAlreadyExists:         "O usuario ja existe",
+	err_domain.UserInactive:              "O usuario esta inativo",
+	err_domain.EmailNotVerified:          "O email do usuario nao foi verificado",
+	err_domain.AccountLocked:             "Muitas tentativas falhas, a conta esta bloqueada temporariamente",
+	err_domain.PasswordResetRequired:     "Voce precisa redefinir sua senha antes de entrar",
+	err_domain.SelfModificationForbidden: "Voce nao pode desativar, remover nem alterar os papeis da sua propria conta",
+	err_domain.InvalidEmail:              "O email nao e valido",
+	err_domain.ValidationFailed:          "Falha na validacao dos dados",
+	err_domain.UnsupportedMediaType:      "O Content-Type da requisicao nao e valido",
+	err_domain.InvalidCredentials:        "Credenciais incorretas",
+	err_domain.Internal:                  "Erro interno do servidor",
+
+	err_domain.RoleNotFound:          "Papel nao encontrado",
+	err_domain.RoleAlreadyExists:     "O papel ja existe",
+	err_domain.RoleProtected:         "Papeis de sistema nao podem ser removidos nem ter suas permissoes alteradas",
+	err_domain.PermissionNotFound:    "Permissao nao encontrada",
+	err_domain.PermissionDenied:      "Voce nao tem permissao para realizar esta acao",
+	err_domain.UnauthorizedRoleGrant: "Voce nao pode conceder papeis com permissoes que voce nao tem",
+
+	err_domain.EmailVerificationTokenInvalid: "O link de verificacao de email e invalido ou expirou",
+
+	err_domain.RefreshTokenInvalid:    "Refresh token invalido",
+	err_domain.RefreshTokenExpired:    "Refresh token expirado",
+	err_domain.RefreshTokenReused:     "Refresh token reutilizado, a sessao foi revogada",
+	err_domain.AccessTokenInvalid:     "Token invalido",
+	err_domain.AuthenticationRequired: "Autenticacao necessaria",
+
+	err_domain.SigningKeyNotFound:         "Chave de assinatura nao encontrada",
+	err_domain.ActiveSigningKeyRetirement: "A chave de assinatura ativa nao pode ser retirada sem rotacionar antes",
+
+	err_domain.PasswordResetTokenInvalid: "O token de recuperacao de senha e invalido ou expirou",
+	err_domain.CurrentPasswordInvalid:    "A senha atual esta incorreta",
+
+	err_domain.PasswordTooShort:         "A senha e muito curta",
+	err_domain.PasswordTooLong:          "A senha e muito longa",
+	err_domain.PasswordMissingUppercase: "A senha deve incluir uma letra maiuscula",
+	err_domain.PasswordMissingLowercase: "A senha deve incluir uma letra minuscula",
+	err_domain.PasswordMissingDigit:     "A senha deve incluir um digito",
+	err_domain.PasswordMissingSymbol:    "A senha deve incluir um simbolo",
+	err_domain.PasswordContainsEmail:    "A senha nao pode conter o email",
+	err_domain.PasswordTooCommon:        "A senha e muito comum",
+	err_domain.PasswordBreached:         "A senha aparece em vazamentos de dados conhecidos",
+	err_domain.PasswordTooWeak:          "A senha e muito facil de adivinhar",
+	err_domain.PasswordReused:           "A senha foi usada recentemente",
+
+	err_domain.MFAAlreadyEnabled:     "O segundo fator ja esta habilitado",
+	err_domain.MFANotEnabled:         "O segundo fator nao esta habilitado",
+	err_domain.MFAEnrollmentNotFound: "Nao ha um cadastro de segundo fator pendente",
+	err_domain.MFAInvalidCode:        "Codigo de verificacao invalido",
+	err_domain.MFAChallengeInvalid:   "O desafio de segundo fator e invalido ou expirou",
+
+	err_domain.WebAuthnChallengeInvalid:   "O desafio WebAuthn e invalido ou expirou",
+	err_domain.WebAuthnVerificationFailed: "Nao foi possivel verificar a resposta do autenticador",
+	err_domain.WebAuthnCredentialNotFound: "Credencial WebAuthn nao encontrada",
+	err_domain.WebAuthnCredentialExists:   "A credencial WebAuthn ja esta registrada",
+	err_domain.WebAuthnCredentialCloned:   "O contador de assinaturas da credencial retrocedeu, possivel autenticador clonado",
+
+	err_domain.InvalidClient:            "Cliente invalido ou nao autenticado",
+	err_domain.InvalidRedirectURI:       "A redirect_uri nao esta registrada para o cliente",
+	err_domain.InvalidScope:             "Scope nao permitido para o cliente",
+	err_domain.UnauthorizedGrant:        "O cliente nao tem permissao para este grant type",
+	err_domain.InvalidAuthorizationCode: "Codigo de autorizacao invalido ou expirado",
+	err_domain.InvalidCodeVerifier:      "O code_verifier nao corresponde ao code_challenge",
+}
@@ -0,0 +1,15 @@
+package mail
+
+import "context"
+
+// Message email de texto plano
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender entrega emails; permite cambiar SMTP por otro proveedor o por un doble en memoria
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
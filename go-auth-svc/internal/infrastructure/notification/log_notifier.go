@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+)
+
+// LogNotifier escribe en el log los enlaces en lugar de enviarlos.
+// Solo sirve para desarrollo: los tokens quedan en los logs.
+type LogNotifier struct {
+	config MailNotifierConfig
+}
+
+func NewLogNotifier(config MailNotifierConfig) *LogNotifier {
+	return &LogNotifier{config: config}
+}
+
+func (n *LogNotifier) SendPasswordReset(ctx context.Context, user *entities.User, token string, expiresAt time.Time) error {
+	log.Printf("Password reset requested for %s, link valid until %s: %s", user.Email, expiresAt.Format(time.RFC3339), withToken(n.config.PasswordResetURL, token))
+	return nil
+}
+
+func (n *LogNotifier) SendEmailVerification(ctx context.Context, user *entities.User, token string, expiresAt time.Time) error {
+	log.Printf("Email verification for %s, link valid until %s: %s", user.Email, expiresAt.Format(time.RFC3339), withToken(n.config.EmailVerificationURL, token))
+	return nil
+}
+
+// withToken agrega el token a la URL como parámetro de query
+func withToken(rawURL, token string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL + "?token=" + url.QueryEscape(token)
+	}
+	query := parsed.Query()
+	query.Set("token", token)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/infrastructure/mail"
+)
+
+// MailNotifierConfig URLs del frontend a las que se agrega el token como parámetro "token"
+type MailNotifierConfig struct {
+	PasswordResetURL     string
+	EmailVerificationURL string
+}
+
+// MailNotifier entrega los enlaces al usuario por email
+type MailNotifier struct {
+	sender mail.Sender
+	config MailNotifierConfig
+}
+
+func NewMailNotifier(sender mail.Sender, config MailNotifierConfig) *MailNotifier {
+	return &MailNotifier{sender: sender, config: config}
+}
+
+func (n *MailNotifier) SendPasswordReset(ctx context.Context, user *entities.User, token string, expiresAt time.Time) error {
+	return n.sender.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("We received a request to reset your password.\n\nOpen this link to choose a new one:\n%s\n\nThe link expires at %s. If you did not request it, ignore this email.\n",
+			withToken(n.config.PasswordResetURL, token), expiresAt.UTC().Format(time.RFC1123)),
+	})
+}
+
+func (n *MailNotifier) SendEmailVerification(ctx context.Context, user *entities.User, token string, expiresAt time.Time) error {
+	return n.sender.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Confirm your email address by opening this link:\n%s\n\nThe link expires at %s.\n",
+			withToken(n.config.EmailVerificationURL, token), expiresAt.UTC().Format(time.RFC1123)),
+	})
+}
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+
+	"poc-auth-svc/internal/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	usersCollection               = "users"
+	refreshTokensCollection       = "refresh_tokens"
+	revokedTokensCollection       = "revoked_tokens"
+	clientsCollection             = "oauth_clients"
+	authorizationCodesCollection  = "authorization_codes"
+	webAuthnCredentialsCollection = "webauthn_credentials"
+	webAuthnSessionsCollection    = "webauthn_sessions"
+	passwordResetTokensCollection = "password_reset_tokens"
+	loginAttemptsCollection       = "login_attempts"
+	rolesCollection               = "roles"
+	permissionsCollection         = "permissions"
+	signingKeysCollection         = "signing_keys"
+	migrationsCollection          = "migrations"
+)
+
+// userEmailUniqueIndex nombre del índice único sobre la forma canónica del email
+const userEmailUniqueIndex = "email_canonical_unique"
+
+// collectionIndexes índices requeridos por cada colección
+var collectionIndexes = map[string][]mongo.IndexModel{
+	usersCollection: {
+		// Único sobre la forma canónica: es lo que impide registrar dos veces el mismo email en paralelo.
+		// Parcial para que los documentos que MigrateUserEmails todavía no completó no choquen entre sí.
+		{
+			Keys: bson.D{{Key: "email_canonical", Value: 1}},
+			Options: options.Index().SetName(userEmailUniqueIndex).SetUnique(true).
+				SetPartialFilterExpression(bson.M{"email_canonical": bson.M{"$type": "string"}}),
+		},
+		// Un índice por cada orden del listado; _id desempata y permite seguir el cursor sin ordenar en memoria.
+		// El orden por email usa el índice único anterior.
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "updated_at", Value: 1}, {Key: "_id", Value: 1}}},
+		// El filtro por rol es un $or entre roles y el role de los documentos anteriores: cada rama necesita
+		// su propio índice, y ambos terminan en el orden por defecto para combinarlas sin ordenar en memoria
+		{Keys: bson.D{{Key: "roles", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "role", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "is_active", Value: 1}, {Key: "created_at", Value: 1}}},
+	},
+	refreshTokensCollection: {
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "family_id", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		// TTL: mongo elimina los tokens una vez expirados
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	revokedTokensCollection: {
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	authorizationCodesCollection: {
+		{Keys: bson.D{{Key: "code_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	webAuthnCredentialsCollection: {
+		{Keys: bson.D{{Key: "credential_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	},
+	webAuthnSessionsCollection: {
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	passwordResetTokensCollection: {
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	loginAttemptsCollection: {
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	signingKeysCollection: {
+		// A lo sumo una clave activa entre todas las réplicas
+		{
+			Keys: bson.D{{Key: "status", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": entities.SigningKeyStatusActive}),
+		},
+		// TTL: mongo elimina las claves de verificación una vez retiradas; la activa no tiene retires_at
+		{Keys: bson.D{{Key: "retires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+}
+
+// EnsureIndexes crea los índices de todas las colecciones; es idempotente
+func EnsureIndexes(ctx context.Context, db *mongo.Database) error {
+	for collection, models := range collectionIndexes {
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+)
+
+// memoryLoginAttemptRepository guarda los contadores en memoria del proceso; sirve para una sola
+// instancia o para tests, ya que cada réplica llevaría su propia cuenta
+type memoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]entities.LoginAttempt
+}
+
+func NewMemoryLoginAttemptRepository() repositories.LoginAttemptRepository {
+	return &memoryLoginAttemptRepository{
+		attempts: make(map[string]entities.LoginAttempt),
+	}
+}
+
+// Get implements repositories.LoginAttemptRepository.
+func (m *memoryLoginAttemptRepository) Get(ctx context.Context, key string) (*entities.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	attempt, ok := m.attempts[key]
+	if !ok || attempt.IsExpired(time.Now()) {
+		return nil, nil
+	}
+	return &attempt, nil
+}
+
+// RecordFailure implements repositories.LoginAttemptRepository.
+func (m *memoryLoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*entities.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pruneExpired(at)
+	attempt, ok := m.attempts[key]
+	if !ok {
+		attempt = entities.LoginAttempt{Key: key}
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = at
+	attempt.ExpiresAt = at.Add(window)
+	if attempt.LockedUntil != nil && attempt.LockedUntil.After(attempt.ExpiresAt) {
+		attempt.ExpiresAt = *attempt.LockedUntil
+	}
+	m.attempts[key] = attempt
+	return &attempt, nil
+}
+
+// Lock implements repositories.LoginAttemptRepository.
+func (m *memoryLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	attempt, ok := m.attempts[key]
+	if !ok {
+		return nil
+	}
+	attempt.LockedUntil = &until
+	if until.After(attempt.ExpiresAt) {
+		attempt.ExpiresAt = until
+	}
+	m.attempts[key] = attempt
+	return nil
+}
+
+// Reset implements repositories.LoginAttemptRepository.
+func (m *memoryLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.attempts, key)
+	return nil
+}
+
+// pruneExpired descarta los contadores vencidos para que el mapa no crezca sin límite
+func (m *memoryLoginAttemptRepository) pruneExpired(now time.Time) {
+	for key, attempt := range m.attempts {
+		if attempt.IsExpired(now) {
+			delete(m.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoAuthorizationCodeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoAuthorizationCodeRepository(db *mongo.Database) repositories.AuthorizationCodeRepository {
+	return &mongoAuthorizationCodeRepository{
+		collection: db.Collection(authorizationCodesCollection),
+	}
+}
+
+// Create implements repositories.AuthorizationCodeRepository.
+func (m *mongoAuthorizationCodeRepository) Create(ctx context.Context, code *entities.AuthorizationCode) error {
+	_, err := m.collection.InsertOne(ctx, code)
+	return err
+}
+
+// Consume implements repositories.AuthorizationCodeRepository.
+func (m *mongoAuthorizationCodeRepository) Consume(ctx context.Context, codeHash string) (*entities.AuthorizationCode, error) {
+	filter := bson.M{"code_hash": codeHash, "used_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"used_at": time.Now()}}
+	var code entities.AuthorizationCode
+	if err := m.collection.FindOneAndUpdate(ctx, filter, update).Decode(&code); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrAuthorizationCodeNotFound
+		}
+		return nil, err
+	}
+	return &code, nil
+}
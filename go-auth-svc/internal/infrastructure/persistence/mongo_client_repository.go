@@ -0,0 +1,66 @@
+package persistence
+
+import (
+	"context"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoClientRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoClientRepository(db *mongo.Database) repositories.ClientRepository {
+	return &mongoClientRepository{
+		collection: db.Collection(clientsCollection),
+	}
+}
+
+// Create implements repositories.ClientRepository.
+func (m *mongoClientRepository) Create(ctx context.Context, client *entities.Client) error {
+	_, err := m.collection.InsertOne(ctx, client)
+	return err
+}
+
+// GetByID implements repositories.ClientRepository.
+func (m *mongoClientRepository) GetByID(ctx context.Context, id string) (*entities.Client, error) {
+	var client entities.Client
+	if err := m.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&client); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrClientNotFound
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+// List implements repositories.ClientRepository.
+func (m *mongoClientRepository) List(ctx context.Context) ([]*entities.Client, error) {
+	cursor, err := m.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	clients := make([]*entities.Client, 0)
+	if err := cursor.All(ctx, &clients); err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// Update implements repositories.ClientRepository.
+func (m *mongoClientRepository) Update(ctx context.Context, client *entities.Client) error {
+	filter := bson.M{"_id": client.ID}
+	update := bson.M{"$set": client}
+	_, err := m.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// Delete implements repositories.ClientRepository.
+func (m *mongoClientRepository) Delete(ctx context.Context, id string) error {
+	_, err := m.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoLoginAttemptRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoLoginAttemptRepository(db *mongo.Database) repositories.LoginAttemptRepository {
+	return &mongoLoginAttemptRepository{
+		collection: db.Collection(loginAttemptsCollection),
+	}
+}
+
+// Get implements repositories.LoginAttemptRepository.
+func (m *mongoLoginAttemptRepository) Get(ctx context.Context, key string) (*entities.LoginAttempt, error) {
+	// El monitor TTL de mongo corre cada minuto, por eso también se filtra por expires_at
+	filter := bson.M{"_id": key, "expires_at": bson.M{"$gt": time.Now()}}
+	var attempt entities.LoginAttempt
+	if err := m.collection.FindOne(ctx, filter).Decode(&attempt); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// RecordFailure implements repositories.LoginAttemptRepository.
+func (m *mongoLoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*entities.LoginAttempt, error) {
+	// Update con pipeline para reiniciar en la misma operación un contador expirado que el TTL aún no borró
+	active := bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$expires_at", time.Time{}}}, at}}
+	update := bson.A{bson.M{"$set": bson.M{
+		"failures":        bson.M{"$cond": bson.A{active, bson.M{"$add": bson.A{"$failures", 1}}, 1}},
+		"locked_until":    bson.M{"$cond": bson.A{active, "$locked_until", "$$REMOVE"}},
+		"last_failure_at": at,
+		"expires_at":      bson.M{"$max": bson.A{at.Add(window), bson.M{"$ifNull": bson.A{"$locked_until", time.Time{}}}}},
+	}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var attempt entities.LoginAttempt
+	if err := m.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&attempt); err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// Lock implements repositories.LoginAttemptRepository.
+func (m *mongoLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	update := bson.M{
+		"$set": bson.M{"locked_until": until},
+		"$max": bson.M{"expires_at": until},
+	}
+	_, err := m.collection.UpdateOne(ctx, bson.M{"_id": key}, update)
+	return err
+}
+
+// Reset implements repositories.LoginAttemptRepository.
+func (m *mongoLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	_, err := m.collection.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoPasswordResetTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoPasswordResetTokenRepository(db *mongo.Database) repositories.PasswordResetTokenRepository {
+	return &mongoPasswordResetTokenRepository{
+		collection: db.Collection(passwordResetTokensCollection),
+	}
+}
+
+// Create implements repositories.PasswordResetTokenRepository.
+func (m *mongoPasswordResetTokenRepository) Create(ctx context.Context, token *entities.PasswordResetToken) error {
+	_, err := m.collection.InsertOne(ctx, token)
+	return err
+}
+
+// GetByHash implements repositories.PasswordResetTokenRepository.
+func (m *mongoPasswordResetTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entities.PasswordResetToken, error) {
+	filter := bson.M{"token_hash": tokenHash, "used_at": bson.M{"$exists": false}}
+	var token entities.PasswordResetToken
+	if err := m.collection.FindOne(ctx, filter).Decode(&token); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrPasswordResetTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Consume implements repositories.PasswordResetTokenRepository.
+func (m *mongoPasswordResetTokenRepository) Consume(ctx context.Context, tokenHash string) (*entities.PasswordResetToken, error) {
+	filter := bson.M{"token_hash": tokenHash, "used_at": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"used_at": time.Now()}}
+	var token entities.PasswordResetToken
+	if err := m.collection.FindOneAndUpdate(ctx, filter, update).Decode(&token); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrPasswordResetTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// DeleteByUser implements repositories.PasswordResetTokenRepository.
+func (m *mongoPasswordResetTokenRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := m.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
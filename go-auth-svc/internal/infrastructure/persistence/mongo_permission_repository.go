@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"context"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoPermissionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoPermissionRepository(db *mongo.Database) repositories.PermissionRepository {
+	return &mongoPermissionRepository{
+		collection: db.Collection(permissionsCollection),
+	}
+}
+
+// Save implements repositories.PermissionRepository.
+func (m *mongoPermissionRepository) Save(ctx context.Context, permission *entities.Permission) error {
+	filter := bson.M{"_id": permission.Name}
+	update := bson.M{"$set": bson.M{"description": permission.Description}}
+	_, err := m.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// GetByNames implements repositories.PermissionRepository.
+func (m *mongoPermissionRepository) GetByNames(ctx context.Context, names []string) ([]*entities.Permission, error) {
+	return m.find(ctx, bson.M{"_id": bson.M{"$in": names}})
+}
+
+// List implements repositories.PermissionRepository.
+func (m *mongoPermissionRepository) List(ctx context.Context) ([]*entities.Permission, error) {
+	return m.find(ctx, bson.M{})
+}
+
+func (m *mongoPermissionRepository) find(ctx context.Context, filter bson.M) ([]*entities.Permission, error) {
+	cursor, err := m.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	permissions := make([]*entities.Permission, 0)
+	if err := cursor.All(ctx, &permissions); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
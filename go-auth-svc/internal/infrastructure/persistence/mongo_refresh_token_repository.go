@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoRefreshTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoRefreshTokenRepository(db *mongo.Database) repositories.RefreshTokenRepository {
+	return &mongoRefreshTokenRepository{
+		collection: db.Collection(refreshTokensCollection),
+	}
+}
+
+// Create implements repositories.RefreshTokenRepository.
+func (m *mongoRefreshTokenRepository) Create(ctx context.Context, token *entities.RefreshToken) error {
+	_, err := m.collection.InsertOne(ctx, token)
+	return err
+}
+
+// GetByHash implements repositories.RefreshTokenRepository.
+func (m *mongoRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	if err := m.collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&token); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrRefreshTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRotated implements repositories.RefreshTokenRepository.
+func (m *mongoRefreshTokenRepository) MarkRotated(ctx context.Context, id, replacedBy string, at time.Time) (bool, error) {
+	filter := bson.M{
+		"_id":        id,
+		"rotated_at": bson.M{"$exists": false},
+		"revoked_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"rotated_at": at, "replaced_by": replacedBy}}
+	result, err := m.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// RevokeFamily implements repositories.RefreshTokenRepository.
+func (m *mongoRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return m.revokeMany(ctx, bson.M{"family_id": familyID})
+}
+
+// RevokeAllForUser implements repositories.RefreshTokenRepository.
+func (m *mongoRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	return m.revokeMany(ctx, bson.M{"user_id": userID})
+}
+
+func (m *mongoRefreshTokenRepository) revokeMany(ctx context.Context, filter bson.M) error {
+	filter["revoked_at"] = bson.M{"$exists": false}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+	_, err := m.collection.UpdateMany(ctx, filter, update)
+	return err
+}
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoRoleRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoRoleRepository(db *mongo.Database) repositories.RoleRepository {
+	return &mongoRoleRepository{
+		collection: db.Collection(rolesCollection),
+	}
+}
+
+// Create implements repositories.RoleRepository.
+func (m *mongoRoleRepository) Create(ctx context.Context, role *entities.Role) error {
+	if _, err := m.collection.InsertOne(ctx, role); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repositories.ErrDuplicateRole
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateIfNotExists implements repositories.RoleRepository.
+func (m *mongoRoleRepository) CreateIfNotExists(ctx context.Context, role *entities.Role) error {
+	filter := bson.M{"_id": role.Name}
+	update := bson.M{"$setOnInsert": role}
+	_, err := m.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// GetByName implements repositories.RoleRepository.
+func (m *mongoRoleRepository) GetByName(ctx context.Context, name string) (*entities.Role, error) {
+	var role entities.Role
+	if err := m.collection.FindOne(ctx, bson.M{"_id": name}).Decode(&role); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrRoleNotFound
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetByNames implements repositories.RoleRepository.
+func (m *mongoRoleRepository) GetByNames(ctx context.Context, names []string) ([]*entities.Role, error) {
+	return m.find(ctx, bson.M{"_id": bson.M{"$in": names}})
+}
+
+// List implements repositories.RoleRepository.
+func (m *mongoRoleRepository) List(ctx context.Context) ([]*entities.Role, error) {
+	return m.find(ctx, bson.M{})
+}
+
+// Update implements repositories.RoleRepository.
+func (m *mongoRoleRepository) Update(ctx context.Context, role *entities.Role) error {
+	filter := bson.M{"_id": role.Name}
+	update := bson.M{"$set": role}
+	result, err := m.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrRoleNotFound
+	}
+	return nil
+}
+
+// Delete implements repositories.RoleRepository.
+func (m *mongoRoleRepository) Delete(ctx context.Context, name string) error {
+	result, err := m.collection.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return repositories.ErrRoleNotFound
+	}
+	return nil
+}
+
+func (m *mongoRoleRepository) find(ctx context.Context, filter bson.M) ([]*entities.Role, error) {
+	cursor, err := m.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]*entities.Role, 0)
+	if err := cursor.All(ctx, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
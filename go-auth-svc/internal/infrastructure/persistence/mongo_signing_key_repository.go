@@ -0,0 +1,78 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoSigningKeyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoSigningKeyRepository(db *mongo.Database) repositories.SigningKeyRepository {
+	return &mongoSigningKeyRepository{
+		collection: db.Collection(signingKeysCollection),
+	}
+}
+
+// List implements repositories.SigningKeyRepository.
+func (m *mongoSigningKeyRepository) List(ctx context.Context) ([]*entities.SigningKeyRecord, error) {
+	cursor, err := m.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*entities.SigningKeyRecord, 0)
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Create implements repositories.SigningKeyRepository.
+func (m *mongoSigningKeyRepository) Create(ctx context.Context, key *entities.SigningKeyRecord) error {
+	if _, err := m.collection.InsertOne(ctx, key); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repositories.ErrSigningKeyConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// Rotate implements repositories.SigningKeyRepository.
+// El índice único sobre la clave activa impide que dos réplicas promuevan claves distintas: solo
+// la que logra degradar a previousID inserta la nueva.
+func (m *mongoSigningKeyRepository) Rotate(ctx context.Context, previousID string, next *entities.SigningKeyRecord, retiresAt time.Time) error {
+	result, err := m.collection.UpdateOne(ctx,
+		bson.M{"_id": previousID, "status": entities.SigningKeyStatusActive},
+		bson.M{"$set": bson.M{"status": entities.SigningKeyStatusVerifying, "retires_at": retiresAt}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrSigningKeyConflict
+	}
+	return m.Create(ctx, next)
+}
+
+// Retire implements repositories.SigningKeyRepository.
+func (m *mongoSigningKeyRepository) Retire(ctx context.Context, id string, retiresAt time.Time) error {
+	result, err := m.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": entities.SigningKeyStatusVerifying},
+		bson.M{"$set": bson.M{"retires_at": retiresAt}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrSigningKeyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	revocationKindToken = "token"
+	revocationKindUser  = "user"
+)
+
+type mongoTokenRevocationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoTokenRevocationRepository(db *mongo.Database) repositories.TokenRevocationRepository {
+	return &mongoTokenRevocationRepository{
+		collection: db.Collection(revokedTokensCollection),
+	}
+}
+
+// RevokeToken implements repositories.TokenRevocationRepository.
+func (m *mongoTokenRevocationRepository) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	filter := bson.M{"_id": jti}
+	update := bson.M{"$set": bson.M{
+		"kind":       revocationKindToken,
+		"expires_at": expiresAt,
+	}}
+	_, err := m.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// RevokeUser implements repositories.TokenRevocationRepository.
+func (m *mongoTokenRevocationRepository) RevokeUser(ctx context.Context, userID string, issuedBefore, expiresAt time.Time) error {
+	filter := bson.M{"_id": userRevocationID(userID)}
+	update := bson.M{
+		"$set": bson.M{"kind": revocationKindUser, "user_id": userID},
+		"$max": bson.M{"issued_before": issuedBefore, "expires_at": expiresAt},
+	}
+	_, err := m.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// IsRevoked implements repositories.TokenRevocationRepository.
+func (m *mongoTokenRevocationRepository) IsRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"_id": jti},
+		bson.M{"_id": userRevocationID(userID), "issued_before": bson.M{"$gt": issuedAt}},
+	}}
+	count, err := m.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func userRevocationID(userID string) string {
+	return revocationKindUser + ":" + userID
+}
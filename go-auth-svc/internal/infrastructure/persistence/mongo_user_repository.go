@@ -2,14 +2,22 @@ package persistence
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"poc-auth-svc/internal/domain/entities"
 	err_domain "poc-auth-svc/internal/domain/errors"
 	"poc-auth-svc/internal/domain/repositories"
+	"poc-auth-svc/internal/domain/valueobjects"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type mongoUserRepository struct {
@@ -18,24 +26,24 @@ type mongoUserRepository struct {
 
 func NewMongoUserRepository(db *mongo.Database) repositories.UserRepository {
 	return &mongoUserRepository{
-		collection: db.Collection("users"),
+		collection: db.Collection(usersCollection),
 	}
 }
 
 // Create implements repositories.UserRepository.
 func (m *mongoUserRepository) Create(ctx context.Context, user *entities.User) error {
 	_, err := m.collection.InsertOne(ctx, user)
-	return err
+	return userWriteError(err)
 }
 
 // GetByEmail implements repositories.UserRepository.
-func (m *mongoUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+func (m *mongoUserRepository) GetByEmail(ctx context.Context, email valueobjects.Email) (*entities.User, error) {
 	var user entities.User
-	if err := m.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user); err != nil {
+	if err := m.collection.FindOne(ctx, bson.M{"email_canonical": email.Canonical()}).Decode(&user); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New(err_domain.GetMessage(err_domain.UserNotFound))
+			return nil, repositories.ErrUserNotFound
 		}
-		return nil, err
+		return nil, storeError(err)
 	}
 	return &user, nil
 }
@@ -45,9 +53,9 @@ func (m *mongoUserRepository) GetByID(ctx context.Context, id string) (*entities
 	var user entities.User
 	if err := m.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&user); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New(err_domain.GetMessage(err_domain.UserNotFound))
+			return nil, repositories.ErrUserNotFound
 		}
-		return nil, err
+		return nil, storeError(err)
 	}
 	return &user, nil
 }
@@ -57,11 +65,212 @@ func (m *mongoUserRepository) Update(ctx context.Context, user *entities.User) e
 	filter := bson.M{"_id": user.ID}
 	update := bson.M{"$set": user}
 	_, err := m.collection.UpdateOne(ctx, filter, update)
-	return err
+	return userWriteError(err)
 }
 
 // Delete implements repositories.UserRepository.
 func (m *mongoUserRepository) Delete(ctx context.Context, id string) error {
 	_, err := m.collection.DeleteOne(ctx, bson.M{"_id": id})
-	return err
+	return storeError(err)
+}
+
+// storeError envuelve los errores del driver como error interno de dominio, conservando la causa para los logs
+func storeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return err_domain.Wrap(err_domain.Internal, err)
+}
+
+// duplicateKeyErrorCode código de mongo para la violación de un índice único
+const duplicateKeyErrorCode = 11000
+
+// userWriteError traduce la violación del índice único del email canónico a repositories.ErrDuplicateEmail.
+// Se reconoce por el keyPattern del error y no por el mensaje, que cambia entre versiones del servidor.
+func userWriteError(err error) error {
+	var writeErr mongo.WriteException
+	if errors.As(err, &writeErr) {
+		for _, we := range writeErr.WriteErrors {
+			if we.Code != duplicateKeyErrorCode {
+				continue
+			}
+			if _, lookupErr := we.Raw.LookupErr("keyPattern", "email_canonical"); lookupErr == nil {
+				return repositories.ErrDuplicateEmail
+			}
+		}
+	}
+	return storeError(err)
+}
+
+// userCursor posición de la última fila de una página. Guarda el orden para rechazar cursores
+// reutilizados con otro criterio, que saltarían o repetirían usuarios.
+type userCursor struct {
+	SortBy     repositories.UserSortField `json:"s"`
+	Descending bool                       `json:"d"`
+	Value      string                     `json:"v"`
+	ID         string                     `json:"id"`
+}
+
+// List implements repositories.UserRepository.
+func (m *mongoUserRepository) List(ctx context.Context, filter repositories.UserFilter, page repositories.Page) (*repositories.UserPage, error) {
+	if page.SortBy == "" {
+		page.SortBy = repositories.UserSortCreatedAt
+	}
+	if page.SortBy != repositories.UserSortCreatedAt && page.SortBy != repositories.UserSortUpdatedAt && page.SortBy != repositories.UserSortEmail {
+		return nil, err_domain.Wrap(err_domain.ValidationFailed, fmt.Errorf("unsupported user sort field %q", page.SortBy))
+	}
+	if page.Limit <= 0 {
+		return nil, err_domain.Wrap(err_domain.ValidationFailed, fmt.Errorf("page limit must be positive, got %d", page.Limit))
+	}
+
+	query := userFilterQuery(filter)
+	if page.SortBy == repositories.UserSortEmail {
+		// El índice único sobre email_canonical es parcial: solo puede recorrerse si la consulta lo implica.
+		// Quedan fuera los usuarios cuyo email MigrateUserEmails no pudo canonicalizar.
+		query = bson.M{"$and": bson.A{query, bson.M{"email_canonical": bson.M{"$type": "string"}}}}
+	}
+	total, err := m.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	if page.Cursor != "" {
+		after, err := userCursorQuery(page)
+		if err != nil {
+			return nil, err
+		}
+		query = bson.M{"$and": bson.A{query, after}}
+	}
+
+	direction := 1
+	if page.Descending {
+		direction = -1
+	}
+	sort := bson.D{{Key: userSortKey(page.SortBy), Value: direction}}
+	// _id desempata usuarios con el mismo valor de orden para que el cursor sea estable; email_canonical es único
+	if page.SortBy != repositories.UserSortEmail {
+		sort = append(sort, bson.E{Key: "_id", Value: direction})
+	}
+	opts := options.Find().
+		SetSort(sort).
+		SetLimit(int64(page.Limit) + 1)
+	cursor, err := m.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	users := make([]*entities.User, 0)
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, storeError(err)
+	}
+
+	result := &repositories.UserPage{Users: users, Total: total}
+	if len(users) > page.Limit {
+		result.Users = users[:page.Limit]
+		result.NextCursor = encodeUserCursor(page, result.Users[page.Limit-1])
+	}
+	return result, nil
+}
+
+func userFilterQuery(filter repositories.UserFilter) bson.M {
+	conditions := bson.A{}
+	if filter.EmailPrefix != "" {
+		// Prefijo anclado y sin opciones para que recorra el índice único sobre email_canonical; el $type
+		// hace que la consulta cumpla el filtro parcial del índice
+		prefix := regexp.QuoteMeta(strings.ToLower(strings.TrimSpace(filter.EmailPrefix)))
+		conditions = append(conditions, bson.M{"email_canonical": bson.M{"$type": "string", "$regex": "^" + prefix}})
+	}
+	if filter.Role != "" {
+		// Los documentos anteriores a roles solo tienen role
+		conditions = append(conditions, bson.M{"$or": bson.A{bson.M{"roles": filter.Role}, bson.M{"role": filter.Role}}})
+	}
+	if filter.IsActive != nil {
+		conditions = append(conditions, bson.M{"is_active": *filter.IsActive})
+	}
+	if filter.EmailVerified != nil {
+		conditions = append(conditions, bson.M{"email_verified": *filter.EmailVerified})
+	}
+	if created := timeRangeQuery(filter.CreatedFrom, filter.CreatedTo); created != nil {
+		conditions = append(conditions, bson.M{"created_at": created})
+	}
+	if updated := timeRangeQuery(filter.UpdatedFrom, filter.UpdatedTo); updated != nil {
+		conditions = append(conditions, bson.M{"updated_at": updated})
+	}
+	if len(conditions) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"$and": conditions}
+}
+
+// timeRangeQuery intervalo [from, to); devuelve nil si no hay límites
+func timeRangeQuery(from, to *time.Time) bson.M {
+	if from == nil && to == nil {
+		return nil
+	}
+	query := bson.M{}
+	if from != nil {
+		query["$gte"] = *from
+	}
+	if to != nil {
+		query["$lt"] = *to
+	}
+	return query
+}
+
+// userCursorQuery selecciona las filas posteriores al cursor en el orden de la página
+func userCursorQuery(page repositories.Page) (bson.M, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(page.Cursor)
+	if err != nil {
+		return nil, repositories.ErrInvalidCursor
+	}
+	var position userCursor
+	if err := json.Unmarshal(raw, &position); err != nil || position.ID == "" {
+		return nil, repositories.ErrInvalidCursor
+	}
+	if position.SortBy != page.SortBy || position.Descending != page.Descending {
+		return nil, repositories.ErrInvalidCursor
+	}
+
+	var value interface{} = position.Value
+	if page.SortBy != repositories.UserSortEmail {
+		parsed, err := time.Parse(time.RFC3339Nano, position.Value)
+		if err != nil {
+			return nil, repositories.ErrInvalidCursor
+		}
+		value = parsed
+	}
+	operator := "$gt"
+	if page.Descending {
+		operator = "$lt"
+	}
+	field := userSortKey(page.SortBy)
+	if page.SortBy == repositories.UserSortEmail {
+		return bson.M{field: bson.M{operator: value}}, nil
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{operator: value}},
+		bson.M{field: value, "_id": bson.M{operator: position.ID}},
+	}}, nil
+}
+
+// userSortKey campo del documento por el que se ordena. El orden por email usa la forma canónica: la
+// visible conserva las mayúsculas de la parte local y ordenaría "Bob@" antes que "alice@".
+func userSortKey(field repositories.UserSortField) string {
+	if field == repositories.UserSortEmail {
+		return "email_canonical"
+	}
+	return string(field)
+}
+
+func encodeUserCursor(page repositories.Page, last *entities.User) string {
+	position := userCursor{SortBy: page.SortBy, Descending: page.Descending, ID: last.ID}
+	switch page.SortBy {
+	case repositories.UserSortEmail:
+		position.Value = last.EmailCanonical
+	case repositories.UserSortUpdatedAt:
+		position.Value = last.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		position.Value = last.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	raw, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(raw)
 }
@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"context"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoWebAuthnCredentialRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoWebAuthnCredentialRepository(db *mongo.Database) repositories.WebAuthnCredentialRepository {
+	return &mongoWebAuthnCredentialRepository{
+		collection: db.Collection(webAuthnCredentialsCollection),
+	}
+}
+
+// Create implements repositories.WebAuthnCredentialRepository.
+func (m *mongoWebAuthnCredentialRepository) Create(ctx context.Context, credential *entities.WebAuthnCredential) error {
+	if _, err := m.collection.InsertOne(ctx, credential); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repositories.ErrDuplicateWebAuthnCredential
+		}
+		return err
+	}
+	return nil
+}
+
+// GetByCredentialID implements repositories.WebAuthnCredentialRepository.
+func (m *mongoWebAuthnCredentialRepository) GetByCredentialID(ctx context.Context, credentialID string) (*entities.WebAuthnCredential, error) {
+	var credential entities.WebAuthnCredential
+	if err := m.collection.FindOne(ctx, bson.M{"credential_id": credentialID}).Decode(&credential); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrWebAuthnCredentialNotFound
+		}
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// ListByUser implements repositories.WebAuthnCredentialRepository.
+func (m *mongoWebAuthnCredentialRepository) ListByUser(ctx context.Context, userID string) ([]*entities.WebAuthnCredential, error) {
+	cursor, err := m.collection.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	credentials := make([]*entities.WebAuthnCredential, 0)
+	if err := cursor.All(ctx, &credentials); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// UpdateSignCount implements repositories.WebAuthnCredentialRepository.
+func (m *mongoWebAuthnCredentialRepository) UpdateSignCount(ctx context.Context, credential *entities.WebAuthnCredential) error {
+	filter := bson.M{"_id": credential.ID}
+	update := bson.M{"$set": bson.M{"sign_count": credential.SignCount, "last_used_at": credential.LastUsedAt}}
+	_, err := m.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// Delete implements repositories.WebAuthnCredentialRepository.
+func (m *mongoWebAuthnCredentialRepository) Delete(ctx context.Context, userID, id string) error {
+	result, err := m.collection.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return repositories.ErrWebAuthnCredentialNotFound
+	}
+	return nil
+}
+
+// DeleteByUser implements repositories.WebAuthnCredentialRepository.
+func (m *mongoWebAuthnCredentialRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := m.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
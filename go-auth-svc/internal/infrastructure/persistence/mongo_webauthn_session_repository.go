@@ -0,0 +1,39 @@
+package persistence
+
+import (
+	"context"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoWebAuthnSessionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoWebAuthnSessionRepository(db *mongo.Database) repositories.WebAuthnSessionRepository {
+	return &mongoWebAuthnSessionRepository{
+		collection: db.Collection(webAuthnSessionsCollection),
+	}
+}
+
+// Create implements repositories.WebAuthnSessionRepository.
+func (m *mongoWebAuthnSessionRepository) Create(ctx context.Context, session *entities.WebAuthnSession) error {
+	_, err := m.collection.InsertOne(ctx, session)
+	return err
+}
+
+// Consume implements repositories.WebAuthnSessionRepository.
+func (m *mongoWebAuthnSessionRepository) Consume(ctx context.Context, challenge string) (*entities.WebAuthnSession, error) {
+	var session entities.WebAuthnSession
+	if err := m.collection.FindOneAndDelete(ctx, bson.M{"_id": challenge}).Decode(&session); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrWebAuthnSessionNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
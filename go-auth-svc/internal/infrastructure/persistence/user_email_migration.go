@@ -0,0 +1,145 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"poc-auth-svc/internal/domain/valueobjects"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	userEmailMigrationID = "user_emails"
+	// userEmailMigrationVersion se incrementa cuando cambia la forma de calcular el email canónico
+	// con las mismas reglas, para forzar que la migración vuelva a correr
+	userEmailMigrationVersion = 1
+	// legacyUserEmailIndex índice único anterior sobre email, reemplazado por userEmailUniqueIndex
+	legacyUserEmailIndex = "email_unique"
+)
+
+// userEmailMigrationRecord registro de la migración de emails: con qué versión y reglas se completó
+// y si ya se eliminó el índice anterior
+type userEmailMigrationRecord struct {
+	ID                 string    `bson:"_id"`
+	Version            int       `bson:"version"`
+	IDN                bool      `bson:"idn"`
+	ProviderAliases    bool      `bson:"provider_aliases"`
+	LegacyIndexDropped bool      `bson:"legacy_index_dropped"`
+	CompletedAt        time.Time `bson:"completed_at"`
+}
+
+// MigrateUserEmails completa o recalcula las dos formas del email de cada usuario con las reglas actuales.
+// Recorre la colección una sola vez por versión y reglas: los arranques siguientes solo leen su registro
+// en la colección de migraciones, así que cambiar EMAIL_IDN o EMAIL_PROVIDER_ALIASES la vuelve a correr.
+// Los emails inválidos se informan en el log y quedan sin forma canónica: esos usuarios no podrán iniciar
+// sesión hasta corregirlos, y mientras tanto se conserva el índice único anterior sobre email. Dos usuarios
+// con la misma forma canónica hacen fallar la migración, porque el índice único no permite fusionarlos
+// sin intervención manual.
+// Debe correr después de EnsureIndexes para que el índice único detecte esas colisiones.
+func MigrateUserEmails(ctx context.Context, db *mongo.Database, rules valueobjects.EmailRules) error {
+	migrations := db.Collection(migrationsCollection)
+	users := db.Collection(usersCollection)
+
+	var record userEmailMigrationRecord
+	err := migrations.FindOne(ctx, bson.M{"_id": userEmailMigrationID}).Decode(&record)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+	current := err == nil && record.Version == userEmailMigrationVersion &&
+		record.IDN == rules.IDN && record.ProviderAliases == rules.ProviderAliases
+	if current && record.LegacyIndexDropped {
+		return nil
+	}
+	if !current {
+		if err := migrateUserEmails(ctx, users, rules); err != nil {
+			return err
+		}
+		record = userEmailMigrationRecord{
+			ID:                 userEmailMigrationID,
+			Version:            userEmailMigrationVersion,
+			IDN:                rules.IDN,
+			ProviderAliases:    rules.ProviderAliases,
+			LegacyIndexDropped: record.LegacyIndexDropped,
+		}
+	}
+
+	// El índice anterior solo puede eliminarse cuando todos los usuarios quedaron cubiertos por el nuevo
+	pending, err := users.CountDocuments(ctx, bson.M{"email_canonical": bson.M{"$not": bson.M{"$type": "string"}}})
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		log.Printf("Warning: %d users have no canonical email, keeping index %s until they are fixed", pending, legacyUserEmailIndex)
+	} else if !record.LegacyIndexDropped {
+		if err := dropIndexIfExists(ctx, users, legacyUserEmailIndex); err != nil {
+			return err
+		}
+		record.LegacyIndexDropped = true
+	}
+
+	record.CompletedAt = time.Now()
+	_, err = migrations.ReplaceOne(ctx, bson.M{"_id": userEmailMigrationID}, record, options.Replace().SetUpsert(true))
+	return err
+}
+
+// migrateUserEmails recorre los usuarios y solo escribe los documentos cuyo email cambia
+func migrateUserEmails(ctx context.Context, collection *mongo.Collection, rules valueobjects.EmailRules) error {
+	opts := options.Find().
+		SetProjection(bson.M{"email": 1, "email_canonical": 1}).
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	for cursor.Next(ctx) {
+		var document struct {
+			ID             string `bson:"_id"`
+			Email          string `bson:"email"`
+			EmailCanonical string `bson:"email_canonical"`
+		}
+		if err := cursor.Decode(&document); err != nil {
+			return err
+		}
+		email, err := valueobjects.NewEmail(document.Email, rules)
+		if err != nil {
+			log.Printf("User %s has an invalid email %q, skipping canonical email migration", document.ID, document.Email)
+			continue
+		}
+		if email.Display() == document.Email && email.Canonical() == document.EmailCanonical {
+			continue
+		}
+		update := bson.M{"$set": bson.M{"email": email.Display(), "email_canonical": email.Canonical()}}
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": document.ID}, update); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return fmt.Errorf("user %s: email %q has the same canonical form %q as another user", document.ID, document.Email, email.Canonical())
+			}
+			return err
+		}
+		migrated++
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if migrated > 0 {
+		log.Printf("Migrated canonical email of %d users", migrated)
+	}
+	return nil
+}
+
+func dropIndexIfExists(ctx context.Context, collection *mongo.Collection, name string) error {
+	_, err := collection.Indexes().DropOne(ctx, name)
+	var commandErr mongo.CommandError
+	if err != nil && !(errors.As(err, &commandErr) && (commandErr.Name == "IndexNotFound" || commandErr.Name == "NamespaceNotFound")) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+var errInvalidArgon2Hash = errors.New("invalid argon2id hash")
+
+// Argon2Params parámetros de Argon2id; Memory se expresa en KiB
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params valores mínimos recomendados por OWASP para Argon2id
+var DefaultArgon2Params = Argon2Params{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Límites de Argon2Params: argon2.IDKey entra en pánico con 0 iteraciones o 0 hilos, y valores muy
+// altos bloquearían el login o agotarían la memoria. La memoria mínima es la de RFC 9106: 8 KiB por hilo.
+const (
+	maxArgon2Iterations = 64
+	maxArgon2MemoryKiB  = 4 * 1024 * 1024
+)
+
+// Validate verifica que los parámetros puedan usarse con argon2.IDKey
+func (p Argon2Params) Validate() error {
+	if p.Iterations < 1 || p.Iterations > maxArgon2Iterations {
+		return fmt.Errorf("argon2 iterations must be between 1 and %d, got %d", maxArgon2Iterations, p.Iterations)
+	}
+	if p.Parallelism < 1 {
+		return errors.New("argon2 parallelism must be at least 1")
+	}
+	if p.Memory < 8*uint32(p.Parallelism) || p.Memory > maxArgon2MemoryKiB {
+		return fmt.Errorf("argon2 memory must be between %d and %d KiB, got %d", 8*uint32(p.Parallelism), maxArgon2MemoryKiB, p.Memory)
+	}
+	return nil
+}
+
+// Argon2Hasher genera hashes Argon2id en formato PHC:
+// $argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash> con salt y hash en base64 estándar sin relleno
+type Argon2Hasher struct {
+	params Argon2Params
+}
+
+func NewArgon2Hasher(params Argon2Params) *Argon2Hasher {
+	return &Argon2Hasher{params: params}
+}
+
+func (h *Argon2Hasher) Hash(plainText string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(plainText), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.params.Memory,
+		h.params.Iterations,
+		h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2Hasher) Compare(hashedText string, plainText string) bool {
+	params, version, salt, key, err := parseArgon2Hash(hashedText)
+	if err != nil || version != argon2.Version {
+		return false
+	}
+	candidate := argon2.IDKey([]byte(plainText), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(candidate, key) == 1
+}
+
+// NeedsRehash indica si el hash usa otra versión o parámetros más débiles que los configurados
+func (h *Argon2Hasher) NeedsRehash(hashedText string) bool {
+	params, version, salt, key, err := parseArgon2Hash(hashedText)
+	if err != nil {
+		return true
+	}
+	return version != argon2.Version ||
+		params.Memory < h.params.Memory ||
+		params.Iterations < h.params.Iterations ||
+		params.Parallelism < h.params.Parallelism ||
+		uint32(len(salt)) < h.params.SaltLength ||
+		uint32(len(key)) < h.params.KeyLength
+}
+
+func parseArgon2Hash(hashedText string) (Argon2Params, int, []byte, []byte, error) {
+	var params Argon2Params
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, hash
+	parts := strings.Split(hashedText, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, 0, nil, nil, errInvalidArgon2Hash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, 0, nil, nil, errInvalidArgon2Hash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, 0, nil, nil, errInvalidArgon2Hash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, 0, nil, nil, errInvalidArgon2Hash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, 0, nil, nil, errInvalidArgon2Hash
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	if params.Validate() != nil {
+		return params, 0, nil, nil, errInvalidArgon2Hash
+	}
+	return params, version, salt, key, nil
+}
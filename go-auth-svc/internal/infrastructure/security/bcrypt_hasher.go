@@ -2,14 +2,24 @@ package security
 
 import "golang.org/x/crypto/bcrypt"
 
-type BcryptHasher struct{}
+type BcryptHasher struct {
+	cost int
+}
 
 func NewBcryptHasher() *BcryptHasher {
-	return &BcryptHasher{}
+	return NewBcryptHasherWithCost(bcrypt.DefaultCost)
+}
+
+// NewBcryptHasherWithCost un costo fuera del rango de bcrypt se reemplaza por bcrypt.DefaultCost
+func NewBcryptHasherWithCost(cost int) *BcryptHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptHasher{cost: cost}
 }
 
 func (h *BcryptHasher) Hash(plainText string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(plainText), bcrypt.DefaultCost)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(plainText), h.cost)
 	return string(bytes), err
 }
 
@@ -17,3 +27,9 @@ func (h *BcryptHasher) Compare(hashedText string, plainText string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedText), []byte(plainText))
 	return err == nil
 }
+
+// NeedsRehash indica si el hash se generó con un costo menor al configurado
+func (h *BcryptHasher) NeedsRehash(hashedText string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedText))
+	return err != nil || cost < h.cost
+}
@@ -0,0 +1,121 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Identificadores COSE (RFC 9053) de los algoritmos aceptados para credenciales WebAuthn
+const (
+	COSEAlgorithmES256 int64 = -7
+	COSEAlgorithmEdDSA int64 = -8
+	COSEAlgorithmRS256 int64 = -257
+)
+
+// Parámetros de una COSE_Key (RFC 9052 sección 7)
+const (
+	coseKeyType      int64 = 1
+	coseKeyAlgorithm int64 = 3
+	coseKeyCurve     int64 = -1
+	coseKeyX         int64 = -2
+	coseKeyY         int64 = -3
+	coseKeyRSAN      int64 = -1
+	coseKeyRSAE      int64 = -2
+
+	coseKeyTypeOKP int64 = 1
+	coseKeyTypeEC2 int64 = 2
+	coseKeyTypeRSA int64 = 3
+
+	coseCurveP256    int64 = 1
+	coseCurveEd25519 int64 = 6
+)
+
+// SupportedCOSEAlgorithms algoritmos anunciados en pubKeyCredParams, en orden de preferencia
+var SupportedCOSEAlgorithms = []int64{COSEAlgorithmES256, COSEAlgorithmEdDSA, COSEAlgorithmRS256}
+
+// coseKey clave pública decodificada de una COSE_Key
+type coseKey struct {
+	algorithm int64
+	publicKey crypto.PublicKey
+}
+
+// parseCOSEKey decodifica una COSE_Key y devuelve la clave junto con los bytes que siguen a ella
+func parseCOSEKey(data []byte) (*coseKey, []byte, error) {
+	item, rest, err := decodeCBOR(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	params, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return nil, nil, errors.New("cose: key is not a map")
+	}
+	keyType, _ := params[coseKeyType].(int64)
+	algorithm, _ := params[coseKeyAlgorithm].(int64)
+
+	key := &coseKey{algorithm: algorithm}
+	switch {
+	case keyType == coseKeyTypeEC2 && algorithm == COSEAlgorithmES256:
+		curve, _ := params[coseKeyCurve].(int64)
+		x, _ := params[coseKeyX].([]byte)
+		y, _ := params[coseKeyY].([]byte)
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, nil, errors.New("cose: invalid P-256 key")
+		}
+		publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !publicKey.Curve.IsOnCurve(publicKey.X, publicKey.Y) {
+			return nil, nil, errors.New("cose: point is not on curve")
+		}
+		key.publicKey = publicKey
+	case keyType == coseKeyTypeOKP && algorithm == COSEAlgorithmEdDSA:
+		curve, _ := params[coseKeyCurve].(int64)
+		x, _ := params[coseKeyX].([]byte)
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, nil, errors.New("cose: invalid Ed25519 key")
+		}
+		key.publicKey = ed25519.PublicKey(x)
+	case keyType == coseKeyTypeRSA && algorithm == COSEAlgorithmRS256:
+		n, _ := params[coseKeyRSAN].([]byte)
+		e, _ := params[coseKeyRSAE].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, nil, errors.New("cose: invalid RSA key")
+		}
+		key.publicKey = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	default:
+		return nil, nil, fmt.Errorf("cose: unsupported key type %d with algorithm %d", keyType, algorithm)
+	}
+	return key, rest, nil
+}
+
+// verifyCOSESignature verifica la firma de data con la clave y el algoritmo COSE indicados;
+// las firmas ECDSA de WebAuthn vienen codificadas en ASN.1 DER
+func verifyCOSESignature(algorithm int64, publicKey crypto.PublicKey, data, signature []byte) error {
+	switch algorithm {
+	case COSEAlgorithmES256:
+		key, ok := publicKey.(*ecdsa.PublicKey)
+		digest := sha256.Sum256(data)
+		if !ok || !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid ES256 signature")
+		}
+	case COSEAlgorithmEdDSA:
+		key, ok := publicKey.(ed25519.PublicKey)
+		if !ok || !ed25519.Verify(key, data, signature) {
+			return errors.New("invalid EdDSA signature")
+		}
+	case COSEAlgorithmRS256:
+		key, ok := publicKey.(*rsa.PublicKey)
+		digest := sha256.Sum256(data)
+		if !ok || rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) != nil {
+			return errors.New("invalid RS256 signature")
+		}
+	default:
+		return fmt.Errorf("unsupported COSE algorithm %d", algorithm)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package security
+
+import "strings"
+
+const (
+	HashAlgorithmArgon2id = "argon2id"
+	HashAlgorithmBcrypt   = "bcrypt"
+)
+
+// MultiHasher genera hashes con el algoritmo configurado y verifica los de cualquier algoritmo
+// soportado, identificándolo por el prefijo. Permite migrar de bcrypt a Argon2id sin invalidar
+// las contraseñas existentes: NeedsRehash marca los hashes que deben regenerarse en el próximo login.
+type MultiHasher struct {
+	algorithm string
+	argon2    *Argon2Hasher
+	bcrypt    *BcryptHasher
+}
+
+func NewMultiHasher(algorithm string, argon2 *Argon2Hasher, bcrypt *BcryptHasher) *MultiHasher {
+	return &MultiHasher{
+		algorithm: algorithm,
+		argon2:    argon2,
+		bcrypt:    bcrypt,
+	}
+}
+
+func (h *MultiHasher) Hash(plainText string) (string, error) {
+	if h.algorithm == HashAlgorithmBcrypt {
+		return h.bcrypt.Hash(plainText)
+	}
+	return h.argon2.Hash(plainText)
+}
+
+func (h *MultiHasher) Compare(hashedText string, plainText string) bool {
+	switch hashAlgorithm(hashedText) {
+	case HashAlgorithmArgon2id:
+		return h.argon2.Compare(hashedText, plainText)
+	case HashAlgorithmBcrypt:
+		return h.bcrypt.Compare(hashedText, plainText)
+	}
+	return false
+}
+
+// NeedsRehash es true si el hash usa otro algoritmo o parámetros más débiles que los actuales
+func (h *MultiHasher) NeedsRehash(hashedText string) bool {
+	algorithm := hashAlgorithm(hashedText)
+	if algorithm != h.currentAlgorithm() {
+		return true
+	}
+	if algorithm == HashAlgorithmBcrypt {
+		return h.bcrypt.NeedsRehash(hashedText)
+	}
+	return h.argon2.NeedsRehash(hashedText)
+}
+
+func (h *MultiHasher) currentAlgorithm() string {
+	if h.algorithm == HashAlgorithmBcrypt {
+		return HashAlgorithmBcrypt
+	}
+	return HashAlgorithmArgon2id
+}
+
+// hashAlgorithm identifica el esquema por su prefijo: PHC para Argon2id y Modular Crypt Format para bcrypt
+func hashAlgorithm(hashedText string) string {
+	switch {
+	case strings.HasPrefix(hashedText, argon2idPrefix):
+		return HashAlgorithmArgon2id
+	case strings.HasPrefix(hashedText, "$2a$"), strings.HasPrefix(hashedText, "$2b$"), strings.HasPrefix(hashedText, "$2y$"):
+		return HashAlgorithmBcrypt
+	}
+	return ""
+}
@@ -0,0 +1,156 @@
+package security
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sha1HexLength = 40
+	// pwnedRangePrefixLength caracteres del hash que se envían a la API de rangos (k-anonimato)
+	pwnedRangePrefixLength = 5
+	pwnedRangeTimeout      = 5 * time.Second
+)
+
+// pwnedPasswordHash devuelve el SHA-1 en hexadecimal mayúsculas, el formato de Pwned Passwords
+func pwnedPasswordHash(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// PwnedFileChecker busca en un archivo local con el formato de descarga de Pwned Passwords:
+// una línea HASH:CONTADOR por hash SHA-1, ordenadas por hash. El archivo puede pesar decenas de GB,
+// por lo que no se carga en memoria sino que se hace búsqueda binaria con ReadAt sobre los bytes.
+type PwnedFileChecker struct {
+	file *os.File
+	size int64
+}
+
+func NewPwnedFileChecker(path string) (*PwnedFileChecker, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &PwnedFileChecker{file: file, size: info.Size()}, nil
+}
+
+func (c *PwnedFileChecker) Close() error {
+	return c.file.Close()
+}
+
+// IsBreached implements services.BreachedPasswordChecker.
+func (c *PwnedFileChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	target := pwnedPasswordHash(password)
+	low, high := int64(0), c.size
+	for low < high {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		mid := low + (high-low)/2
+		start, end, line, err := c.lineFrom(mid)
+		if err != nil {
+			return false, err
+		}
+		// Ninguna línea empieza dentro de [mid, high): la búsqueda sigue en la mitad inferior
+		if start >= high || line == "" {
+			high = mid
+			continue
+		}
+		hash, _, _ := strings.Cut(line, ":")
+		switch strings.Compare(strings.ToUpper(hash), target) {
+		case 0:
+			return true, nil
+		case -1:
+			low = end
+		default:
+			high = mid
+		}
+	}
+	return false, nil
+}
+
+// lineFrom devuelve la primera línea que empieza en offset o después, junto con su inicio y el inicio de la siguiente
+func (c *PwnedFileChecker) lineFrom(offset int64) (int64, int64, string, error) {
+	start := offset
+	if offset > 0 {
+		// Se lee desde el byte anterior para saber si offset ya es el comienzo de una línea
+		reader := bufio.NewReader(io.NewSectionReader(c.file, offset-1, c.size-offset+1))
+		skipped, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return c.size, c.size, "", nil
+		}
+		if err != nil {
+			return 0, 0, "", err
+		}
+		start = offset - 1 + int64(len(skipped))
+	}
+	reader := bufio.NewReader(io.NewSectionReader(c.file, start, c.size-start))
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return 0, 0, "", err
+	}
+	return start, start + int64(len(line)), strings.TrimSpace(line), nil
+}
+
+// PwnedRangeChecker consulta la API de rangos de Pwned Passwords: solo se envían los primeros
+// cinco caracteres del SHA-1 y la coincidencia se busca localmente entre los sufijos devueltos.
+// BaseURL puede apuntar a un servicio interno que replique la API para no salir a Internet.
+type PwnedRangeChecker struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewPwnedRangeChecker(baseURL string) *PwnedRangeChecker {
+	return &PwnedRangeChecker{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: pwnedRangeTimeout},
+	}
+}
+
+// IsBreached implements services.BreachedPasswordChecker.
+func (c *PwnedRangeChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	hash := pwnedPasswordHash(password)
+	prefix, suffix := hash[:pwnedRangePrefixLength], hash[pwnedRangePrefixLength:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	// El relleno oculta el tamaño real de la respuesta; las entradas de relleno tienen contador 0
+	req.Header.Set("Add-Padding", "true")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("pwned passwords range request failed with status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		candidate, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if len(candidate) != sha1HexLength-pwnedRangePrefixLength || !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		if n, err := strconv.ParseInt(count, 10, 64); err == nil && n == 0 {
+			return false, nil
+		}
+		return true, nil
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,16 @@
+package security
+
+import qrcode "github.com/skip2/go-qrcode"
+
+const qrCodeSize = 256
+
+type QRCodeRenderer struct{}
+
+func NewQRCodeRenderer() *QRCodeRenderer {
+	return &QRCodeRenderer{}
+}
+
+// PNG genera un código QR en formato PNG con el contenido indicado
+func (r *QRCodeRenderer) PNG(content string) ([]byte, error) {
+	return qrcode.Encode(content, qrcode.Medium, qrCodeSize)
+}
@@ -0,0 +1,106 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"poc-auth-svc/internal/domain/valueobjects"
+)
+
+const rsaKeyBits = 2048
+
+// LoadSigningKey lee una clave privada PEM (PKCS#8, PKCS#1 o SEC 1) desde disco.
+// Si kid es vacío se usa el thumbprint RFC 7638 de la clave pública.
+func LoadSigningKey(path, kid string) (*valueobjects.SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	privateKey, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, err
+	}
+	return newSigningKey(kid, privateKey)
+}
+
+// GenerateSigningKey genera en memoria una clave nueva para el algoritmo indicado
+func GenerateSigningKey(algorithm, kid string) (*valueobjects.SigningKey, error) {
+	var (
+		privateKey crypto.PrivateKey
+		err        error
+	)
+	switch algorithm {
+	case valueobjects.AlgorithmHS256:
+		return generateHMACSigningKey(kid)
+	case valueobjects.AlgorithmRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case valueobjects.AlgorithmES256:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case valueobjects.AlgorithmEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newSigningKey(kid, privateKey)
+}
+
+func newSigningKey(kid string, privateKey crypto.PrivateKey) (*valueobjects.SigningKey, error) {
+	key, err := valueobjects.NewAsymmetricSigningKey(kid, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if key.ID == "" {
+		jwk, err := valueobjects.NewJWK(key)
+		if err != nil {
+			return nil, err
+		}
+		if key.ID, err = jwk.Thumbprint(); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func generateHMACSigningKey(kid string) (*valueobjects.SigningKey, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	// Un secreto no tiene thumbprint público; el kid se deriva de un hash que no lo expone
+	if kid == "" {
+		sum := sha256.Sum256(secret)
+		kid = base64.RawURLEncoding.EncodeToString(sum[:12])
+	}
+	return valueobjects.NewHMACSigningKey(kid, secret), nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.PrivateKey, error) {
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, errors.New("unsupported PEM block type " + block.Type)
+	}
+}
@@ -0,0 +1,250 @@
+package security
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"poc-auth-svc/internal/domain/entities"
+	"poc-auth-svc/internal/domain/services"
+)
+
+// Flags de authenticatorData (WebAuthn nivel 2 sección 6.1)
+const (
+	authenticatorFlagUserPresent      byte = 0x01
+	authenticatorFlagUserVerified     byte = 0x04
+	authenticatorFlagAttestedCredData byte = 0x40
+
+	authenticatorDataMinLength = 37
+	aaguidLength               = 16
+)
+
+const (
+	AttestationFormatNone   = "none"
+	AttestationFormatPacked = "packed"
+)
+
+// oidFIDOAAGUID extensión id-fido-gen-ce-aaguid de los certificados de attestation FIDO
+var oidFIDOAAGUID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 45724, 1, 1, 4}
+
+// WebAuthnVerifier implementa la verificación de registro y autenticación para un relying party.
+// Acepta attestation "none" y "packed" (self attestation o certificado x5c); la cadena del
+// certificado no se valida contra el FIDO Metadata Service, solo la firma y sus requisitos básicos.
+type WebAuthnVerifier struct {
+	rpIDHash [32]byte
+	origins  []string
+	// requireUserVerification exige el flag UV; necesario cuando la passkey reemplaza a la contraseña
+	requireUserVerification bool
+}
+
+func NewWebAuthnVerifier(rpID string, origins []string, requireUserVerification bool) *WebAuthnVerifier {
+	return &WebAuthnVerifier{
+		rpIDHash:                sha256.Sum256([]byte(rpID)),
+		origins:                 origins,
+		requireUserVerification: requireUserVerification,
+	}
+}
+
+type collectedClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+type authenticatorData struct {
+	raw          []byte
+	flags        byte
+	signCount    uint32
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+	coseKey      *coseKey
+}
+
+// VerifyRegistration implements services.WebAuthnVerifier.
+func (v *WebAuthnVerifier) VerifyRegistration(clientDataJSON, attestationObject []byte, challenge string) (*services.AttestedCredential, error) {
+	if err := v.verifyClientData(clientDataJSON, entities.WebAuthnCeremonyRegistration, challenge); err != nil {
+		return nil, err
+	}
+
+	item, rest, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, err
+	}
+	attestation, ok := item.(map[interface{}]interface{})
+	if !ok || len(rest) != 0 {
+		return nil, errors.New("malformed attestation object")
+	}
+	format, _ := attestation["fmt"].(string)
+	statement, _ := attestation["attStmt"].(map[interface{}]interface{})
+	rawAuthData, _ := attestation["authData"].([]byte)
+	if statement == nil || rawAuthData == nil {
+		return nil, errors.New("malformed attestation object")
+	}
+
+	authData, err := v.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if authData.flags&authenticatorFlagAttestedCredData == 0 || authData.coseKey == nil {
+		return nil, errors.New("attested credential data missing")
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	switch format {
+	case AttestationFormatNone:
+		if len(statement) != 0 {
+			return nil, errors.New("none attestation must have an empty statement")
+		}
+	case AttestationFormatPacked:
+		if err := verifyPackedAttestation(statement, authData, clientDataHash[:]); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported attestation format %q", format)
+	}
+
+	return &services.AttestedCredential{
+		CredentialID: authData.credentialID,
+		PublicKey:    authData.publicKey,
+		SignCount:    authData.signCount,
+		AAGUID:       authData.aaguid,
+		Format:       format,
+	}, nil
+}
+
+// VerifyAssertion implements services.WebAuthnVerifier.
+func (v *WebAuthnVerifier) VerifyAssertion(clientDataJSON, rawAuthData, signature, publicKey []byte, challenge string) (uint32, error) {
+	if err := v.verifyClientData(clientDataJSON, entities.WebAuthnCeremonyAuthentication, challenge); err != nil {
+		return 0, err
+	}
+	authData, err := v.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return 0, err
+	}
+	key, _, err := parseCOSEKey(publicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte(nil), authData.raw...), clientDataHash[:]...)
+	if err := verifyCOSESignature(key.algorithm, key.publicKey, signed, signature); err != nil {
+		return 0, err
+	}
+	return authData.signCount, nil
+}
+
+func (v *WebAuthnVerifier) verifyClientData(clientDataJSON []byte, ceremony, challenge string) error {
+	var clientData collectedClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return errors.New("malformed clientDataJSON")
+	}
+	if clientData.Type != ceremony {
+		return fmt.Errorf("unexpected client data type %q", clientData.Type)
+	}
+	if subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(challenge)) != 1 {
+		return errors.New("challenge mismatch")
+	}
+	if clientData.CrossOrigin || !slices.Contains(v.origins, clientData.Origin) {
+		return fmt.Errorf("origin %q is not allowed", clientData.Origin)
+	}
+	return nil
+}
+
+// parseAuthenticatorData valida rpIdHash y flags y decodifica los datos de la credencial si vienen
+func (v *WebAuthnVerifier) parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < authenticatorDataMinLength {
+		return nil, errors.New("authenticator data too short")
+	}
+	if subtle.ConstantTimeCompare(raw[:32], v.rpIDHash[:]) != 1 {
+		return nil, errors.New("rpIdHash mismatch")
+	}
+	data := &authenticatorData{
+		raw:       raw,
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	if data.flags&authenticatorFlagUserPresent == 0 {
+		return nil, errors.New("user presence flag not set")
+	}
+	if v.requireUserVerification && data.flags&authenticatorFlagUserVerified == 0 {
+		return nil, errors.New("user verification flag not set")
+	}
+	if data.flags&authenticatorFlagAttestedCredData == 0 {
+		return data, nil
+	}
+
+	rest := raw[authenticatorDataMinLength:]
+	if len(rest) < aaguidLength+2 {
+		return nil, errors.New("attested credential data too short")
+	}
+	data.aaguid = rest[:aaguidLength]
+	idLength := int(binary.BigEndian.Uint16(rest[aaguidLength:]))
+	rest = rest[aaguidLength+2:]
+	if idLength == 0 || len(rest) < idLength {
+		return nil, errors.New("invalid credential id length")
+	}
+	data.credentialID = rest[:idLength]
+	rest = rest[idLength:]
+
+	key, extensions, err := parseCOSEKey(rest)
+	if err != nil {
+		return nil, err
+	}
+	data.coseKey = key
+	data.publicKey = rest[:len(rest)-len(extensions)]
+	return data, nil
+}
+
+// verifyPackedAttestation implementa el formato "packed" (WebAuthn nivel 2 sección 8.2)
+func verifyPackedAttestation(statement map[interface{}]interface{}, authData *authenticatorData, clientDataHash []byte) error {
+	algorithm, _ := statement["alg"].(int64)
+	signature, _ := statement["sig"].([]byte)
+	if signature == nil {
+		return errors.New("packed attestation signature missing")
+	}
+	signed := append(append([]byte(nil), authData.raw...), clientDataHash...)
+
+	chain, hasChain := statement["x5c"].([]interface{})
+	if !hasChain {
+		// Self attestation: firma con la propia clave de la credencial
+		if algorithm != authData.coseKey.algorithm {
+			return errors.New("self attestation algorithm does not match credential key")
+		}
+		return verifyCOSESignature(algorithm, authData.coseKey.publicKey, signed, signature)
+	}
+
+	if len(chain) == 0 {
+		return errors.New("empty x5c chain")
+	}
+	der, _ := chain[0].([]byte)
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return err
+	}
+	if err := verifyCOSESignature(algorithm, certificate.PublicKey, signed, signature); err != nil {
+		return err
+	}
+	if certificate.Version != 3 || certificate.IsCA || !slices.Contains(certificate.Subject.OrganizationalUnit, "Authenticator Attestation") {
+		return errors.New("attestation certificate does not meet packed requirements")
+	}
+	for _, extension := range certificate.Extensions {
+		if !extension.Id.Equal(oidFIDOAAGUID) {
+			continue
+		}
+		var aaguid []byte
+		if _, err := asn1.Unmarshal(extension.Value, &aaguid); err != nil || !bytes.Equal(aaguid, authData.aaguid) {
+			return errors.New("attestation certificate aaguid mismatch")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package security
+
+import (
+	"bytes"
+	"testing"
+
+	"poc-auth-svc/internal/infrastructure/security/webauthntest"
+)
+
+const (
+	testRPID   = "auth.example.com"
+	testOrigin = "https://auth.example.com"
+)
+
+func newTestAuthenticator(t *testing.T) *webauthntest.Authenticator {
+	t.Helper()
+	authenticator, err := webauthntest.NewAuthenticator(testRPID, testOrigin)
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	return authenticator
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	for _, format := range []string{webauthntest.AttestationFormatNone, webauthntest.AttestationFormatPacked} {
+		t.Run(format, func(t *testing.T) {
+			verifier := NewWebAuthnVerifier(testRPID, []string{testOrigin}, true)
+			authenticator := newTestAuthenticator(t)
+
+			registration, err := authenticator.Register("challenge", format)
+			if err != nil {
+				t.Fatalf("Register: %v", err)
+			}
+			credential, err := verifier.VerifyRegistration(registration.ClientDataJSON, registration.AttestationObject, "challenge")
+			if err != nil {
+				t.Fatalf("VerifyRegistration: %v", err)
+			}
+			if !bytes.Equal(credential.CredentialID, authenticator.CredentialID) {
+				t.Errorf("credential id = %x, want %x", credential.CredentialID, authenticator.CredentialID)
+			}
+			if !bytes.Equal(credential.PublicKey, authenticator.PublicKey()) {
+				t.Errorf("public key does not match the authenticator key")
+			}
+			if credential.Format != format {
+				t.Errorf("format = %q, want %q", credential.Format, format)
+			}
+		})
+	}
+}
+
+func TestVerifyRegistrationRejects(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		tamper    func(*webauthntest.Authenticator)
+	}{
+		{name: "wrong origin", challenge: "challenge", tamper: func(a *webauthntest.Authenticator) { a.Origin = "https://evil.example.com" }},
+		{name: "wrong rpIdHash", challenge: "challenge", tamper: func(a *webauthntest.Authenticator) { a.RPID = "evil.example.com" }},
+		{name: "wrong challenge", challenge: "other", tamper: func(*webauthntest.Authenticator) {}},
+		{name: "user not verified", challenge: "challenge", tamper: func(a *webauthntest.Authenticator) { a.UserVerified = false }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewWebAuthnVerifier(testRPID, []string{testOrigin}, true)
+			authenticator := newTestAuthenticator(t)
+			tt.tamper(authenticator)
+
+			registration, err := authenticator.Register(tt.challenge, webauthntest.AttestationFormatPacked)
+			if err != nil {
+				t.Fatalf("Register: %v", err)
+			}
+			if _, err := verifier.VerifyRegistration(registration.ClientDataJSON, registration.AttestationObject, "challenge"); err == nil {
+				t.Fatal("VerifyRegistration succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestVerifyRegistrationRejectsBadPackedSignature(t *testing.T) {
+	verifier := NewWebAuthnVerifier(testRPID, []string{testOrigin}, true)
+	authenticator := newTestAuthenticator(t)
+
+	registration, err := authenticator.Register("challenge", webauthntest.AttestationFormatPacked)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	// Cambiar clientDataJSON después de firmar invalida la firma de la atestación
+	clientDataJSON := bytes.Replace(registration.ClientDataJSON, []byte(`"crossOrigin":false`), []byte(`"crossOrigin":false `), 1)
+	if _, err := verifier.VerifyRegistration(clientDataJSON, registration.AttestationObject, "challenge"); err == nil {
+		t.Fatal("VerifyRegistration succeeded, want error")
+	}
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	verifier := NewWebAuthnVerifier(testRPID, []string{testOrigin}, true)
+	authenticator := newTestAuthenticator(t)
+
+	for want := uint32(1); want <= 2; want++ {
+		assertion, err := authenticator.Assert("challenge")
+		if err != nil {
+			t.Fatalf("Assert: %v", err)
+		}
+		signCount, err := verifier.VerifyAssertion(assertion.ClientDataJSON, assertion.AuthenticatorData, assertion.Signature, authenticator.PublicKey(), "challenge")
+		if err != nil {
+			t.Fatalf("VerifyAssertion: %v", err)
+		}
+		if signCount != want {
+			t.Errorf("sign count = %d, want %d", signCount, want)
+		}
+	}
+}
+
+func TestVerifyAssertionRejects(t *testing.T) {
+	// before modifica el autenticador antes de firmar; tamper, la respuesta ya firmada
+	tests := []struct {
+		name   string
+		before func(*webauthntest.Authenticator)
+		tamper func(*webauthntest.Assertion)
+	}{
+		{name: "wrong origin", before: func(a *webauthntest.Authenticator) { a.Origin = "https://evil.example.com" }},
+		{name: "wrong rpIdHash", before: func(a *webauthntest.Authenticator) { a.RPID = "evil.example.com" }},
+		{name: "user not verified", before: func(a *webauthntest.Authenticator) { a.UserVerified = false }},
+		{name: "bad signature", tamper: func(assertion *webauthntest.Assertion) {
+			assertion.Signature[len(assertion.Signature)-1] ^= 0xff
+		}},
+		{name: "tampered authenticator data", tamper: func(assertion *webauthntest.Assertion) {
+			assertion.AuthenticatorData[len(assertion.AuthenticatorData)-1]++
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewWebAuthnVerifier(testRPID, []string{testOrigin}, true)
+			authenticator := newTestAuthenticator(t)
+			if tt.before != nil {
+				tt.before(authenticator)
+			}
+			assertion, err := authenticator.Assert("challenge")
+			if err != nil {
+				t.Fatalf("Assert: %v", err)
+			}
+			if tt.tamper != nil {
+				tt.tamper(assertion)
+			}
+			if _, err := verifier.VerifyAssertion(assertion.ClientDataJSON, assertion.AuthenticatorData, assertion.Signature, authenticator.PublicKey(), "challenge"); err == nil {
+				t.Fatal("VerifyAssertion succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestDecodeCBORRejectsMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "truncated byte string", data: []byte{0x44, 0x01, 0x02}},
+		{name: "truncated length", data: []byte{0x19, 0x01}},
+		{name: "indefinite length", data: []byte{0x5f, 0x41, 0x00, 0xff}},
+		{name: "duplicate map key", data: []byte{0xa2, 0x01, 0x01, 0x01, 0x02}},
+		{name: "too deep", data: append(bytes.Repeat([]byte{0x81}, 16), 0x00)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := decodeCBOR(tt.data); err == nil {
+				t.Fatal("decodeCBOR succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestParseCOSEKey(t *testing.T) {
+	authenticator := newTestAuthenticator(t)
+	key, rest, err := parseCOSEKey(authenticator.PublicKey())
+	if err != nil {
+		t.Fatalf("parseCOSEKey: %v", err)
+	}
+	if key.algorithm != COSEAlgorithmES256 || len(rest) != 0 {
+		t.Errorf("algorithm = %d, rest = %d bytes; want ES256 and no rest", key.algorithm, len(rest))
+	}
+
+	// Un punto fuera de la curva no es una clave válida
+	invalid := authenticator.PublicKey()
+	invalid[len(invalid)-1] ^= 0xff
+	if _, _, err := parseCOSEKey(invalid); err == nil {
+		t.Error("parseCOSEKey accepted a point outside the curve")
+	}
+}
@@ -0,0 +1,169 @@
+// Package webauthntest provee un autenticador WebAuthn de software para probar las ceremonias
+// de registro y login sin navegador ni llave física.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+)
+
+const (
+	AttestationFormatNone   = "none"
+	AttestationFormatPacked = "packed"
+)
+
+// Flags de authenticator data
+const (
+	flagUserPresent      byte = 0x01
+	flagUserVerified     byte = 0x04
+	flagAttestedCredData byte = 0x40
+)
+
+const (
+	coseAlgorithmES256 = -7
+	coseKeyTypeEC2     = 2
+	coseCurveP256      = 1
+	credentialIDLength = 16
+)
+
+// Authenticator autenticador ES256 con una única credencial. Los campos exportados se pueden
+// modificar entre ceremonias para simular respuestas inválidas: otro origen, otro RP ID o un
+// contador de firmas que retrocede.
+type Authenticator struct {
+	RPID         string
+	Origin       string
+	CredentialID []byte
+	AAGUID       []byte
+	// SignCount último contador informado; Assert lo incrementa antes de firmar
+	SignCount uint32
+	// UserVerified controla el flag UV de las respuestas
+	UserVerified bool
+
+	key *ecdsa.PrivateKey
+}
+
+// Registration respuesta de navigator.credentials.create()
+type Registration struct {
+	ClientDataJSON    []byte
+	AttestationObject []byte
+}
+
+// Assertion respuesta de navigator.credentials.get()
+type Assertion struct {
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+}
+
+func NewAuthenticator(rpID, origin string) (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	credentialID := make([]byte, credentialIDLength)
+	if _, err := rand.Read(credentialID); err != nil {
+		return nil, err
+	}
+	return &Authenticator{
+		RPID:         rpID,
+		Origin:       origin,
+		CredentialID: credentialID,
+		AAGUID:       make([]byte, 16),
+		UserVerified: true,
+		key:          key,
+	}, nil
+}
+
+// Register responde un desafío de registro con la atestación del formato indicado;
+// "packed" usa self attestation, firmando con la clave de la credencial
+func (a *Authenticator) Register(challenge, format string) (*Registration, error) {
+	clientDataJSON, err := a.clientData("webauthn.create", challenge)
+	if err != nil {
+		return nil, err
+	}
+	authData := a.authenticatorData(true)
+
+	statement := cborMap{}
+	if format == AttestationFormatPacked {
+		signature, err := a.sign(authData, clientDataJSON)
+		if err != nil {
+			return nil, err
+		}
+		statement = cborMap{{"alg", coseAlgorithmES256}, {"sig", signature}}
+	}
+	attestationObject := encodeCBOR(cborMap{
+		{"fmt", format},
+		{"attStmt", statement},
+		{"authData", authData},
+	})
+	return &Registration{ClientDataJSON: clientDataJSON, AttestationObject: attestationObject}, nil
+}
+
+// Assert responde un desafío de autenticación incrementando el contador de firmas
+func (a *Authenticator) Assert(challenge string) (*Assertion, error) {
+	clientDataJSON, err := a.clientData("webauthn.get", challenge)
+	if err != nil {
+		return nil, err
+	}
+	a.SignCount++
+	authData := a.authenticatorData(false)
+	signature, err := a.sign(authData, clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &Assertion{ClientDataJSON: clientDataJSON, AuthenticatorData: authData, Signature: signature}, nil
+}
+
+// PublicKey clave pública de la credencial en formato COSE
+func (a *Authenticator) PublicKey() []byte {
+	x := a.key.PublicKey.X.FillBytes(make([]byte, 32))
+	y := a.key.PublicKey.Y.FillBytes(make([]byte, 32))
+	return encodeCBOR(cborMap{
+		{1, coseKeyTypeEC2},
+		{3, coseAlgorithmES256},
+		{-1, coseCurveP256},
+		{-2, x},
+		{-3, y},
+	})
+}
+
+func (a *Authenticator) clientData(ceremony, challenge string) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":        ceremony,
+		"challenge":   challenge,
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+}
+
+// authenticatorData rpIdHash | flags | signCount | [aaguid | credIdLen | credId | clave COSE]
+func (a *Authenticator) authenticatorData(attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	flags := flagUserPresent
+	if a.UserVerified {
+		flags |= flagUserVerified
+	}
+	if attested {
+		flags |= flagAttestedCredData
+	}
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.SignCount)
+	if !attested {
+		return data
+	}
+	data = append(data, a.AAGUID...)
+	data = binary.BigEndian.AppendUint16(data, uint16(len(a.CredentialID)))
+	data = append(data, a.CredentialID...)
+	return append(data, a.PublicKey()...)
+}
+
+// sign firma authData || sha256(clientDataJSON) en DER, como los autenticadores ES256
+func (a *Authenticator) sign(authData, clientDataJSON []byte) ([]byte, error) {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+	return ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+}
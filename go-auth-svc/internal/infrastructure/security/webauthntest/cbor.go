@@ -0,0 +1,58 @@
+package webauthntest
+
+import "encoding/binary"
+
+// cborPair entrada de un mapa CBOR; los mapas se codifican en el orden de la lista
+type cborPair struct {
+	key   interface{}
+	value interface{}
+}
+
+type cborMap []cborPair
+
+// encodeCBOR codifica el subconjunto de CBOR que usan WebAuthn y COSE: enteros, byte strings,
+// text strings, arrays y mapas de longitud definida
+func encodeCBOR(value interface{}) []byte {
+	switch v := value.(type) {
+	case int:
+		if v < 0 {
+			return cborHeader(1, uint64(-1-v))
+		}
+		return cborHeader(0, uint64(v))
+	case []byte:
+		return append(cborHeader(2, uint64(len(v))), v...)
+	case string:
+		return append(cborHeader(3, uint64(len(v))), v...)
+	case []interface{}:
+		out := cborHeader(4, uint64(len(v)))
+		for _, item := range v {
+			out = append(out, encodeCBOR(item)...)
+		}
+		return out
+	case cborMap:
+		out := cborHeader(5, uint64(len(v)))
+		for _, pair := range v {
+			out = append(out, encodeCBOR(pair.key)...)
+			out = append(out, encodeCBOR(pair.value)...)
+		}
+		return out
+	default:
+		panic("webauthntest: unsupported CBOR value")
+	}
+}
+
+func cborHeader(major byte, length uint64) []byte {
+	major <<= 5
+	switch {
+	case length < 24:
+		return []byte{major | byte(length)}
+	case length <= 0xff:
+		return []byte{major | 24, byte(length)}
+	case length <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major | 25}, uint16(length))
+	case length <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major | 26}, uint32(length))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{major | 27}, length)
+	}
+}
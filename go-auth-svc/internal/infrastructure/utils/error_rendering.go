@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"poc-auth-svc/internal/infrastructure/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MIMEApplicationProblemJSON media type de RFC 7807
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ErrorFormat formato de las respuestas de error
+type ErrorFormat string
+
+const (
+	// ErrorFormatStandard envoltorio StandardResponse, el formato histórico de la API
+	ErrorFormatStandard ErrorFormat = "standard"
+	// ErrorFormatProblem documentos problem+json de RFC 7807
+	ErrorFormatProblem ErrorFormat = "problem"
+)
+
+// ErrorRenderer escribe una respuesta de error en un formato concreto
+type ErrorRenderer func(c *fiber.Ctx, status int, code, message string, details interface{}) error
+
+// ProblemDetails documento de RFC 7807. Code y Errors son extensiones: el código de dominio
+// y el detalle de validación que en StandardResponse viaja en details.
+type ProblemDetails struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Detail   string      `json:"detail,omitempty"`
+	Instance string      `json:"instance,omitempty"`
+	Code     string      `json:"code,omitempty"`
+	Errors   interface{} `json:"errors,omitempty"`
+}
+
+type errorRendering struct {
+	format             ErrorFormat
+	problemTypeBaseURI string
+}
+
+var (
+	errorRenderers = map[ErrorFormat]ErrorRenderer{
+		ErrorFormatStandard: renderStandardError,
+		ErrorFormatProblem:  renderProblemError,
+	}
+	errorMediaTypes = map[ErrorFormat]string{
+		ErrorFormatStandard: fiber.MIMEApplicationJSON,
+		ErrorFormatProblem:  MIMEApplicationProblemJSON,
+	}
+	currentErrorRendering = errorRendering{format: ErrorFormatStandard}
+)
+
+// ConfigureErrorRendering fija el formato de error por defecto. problemTypeBaseURI es la base de la URI type
+// de los documentos problem+json, a la que se agrega el código de error en minúsculas; vacía usa about:blank.
+// Se llama una vez al arrancar, antes de atender peticiones.
+func ConfigureErrorRendering(format ErrorFormat, problemTypeBaseURI string) error {
+	if _, ok := errorRenderers[format]; !ok {
+		return fmt.Errorf("unsupported error format %q", format)
+	}
+	currentErrorRendering = errorRendering{format: format, problemTypeBaseURI: problemTypeBaseURI}
+	return nil
+}
+
+// negotiateErrorFormat el formato configurado se ofrece primero, así gana ante Accept: */* o sin Accept;
+// un cliente que pide explícitamente el otro media type lo recibe
+func negotiateErrorFormat(c *fiber.Ctx) ErrorFormat {
+	offers := []ErrorFormat{currentErrorRendering.format}
+	for format := range errorMediaTypes {
+		if format != currentErrorRendering.format {
+			offers = append(offers, format)
+		}
+	}
+	mediaTypes := make([]string, 0, len(offers))
+	for _, format := range offers {
+		mediaTypes = append(mediaTypes, errorMediaTypes[format])
+	}
+	accepted := c.Accepts(mediaTypes...)
+	for _, format := range offers {
+		if errorMediaTypes[format] == accepted {
+			return format
+		}
+	}
+	return currentErrorRendering.format
+}
+
+func renderStandardError(c *fiber.Ctx, status int, code, message string, details interface{}) error {
+	response := StandardResponse{
+		Success:   false,
+		Message:   Translate(c, i18n.MsgRequestFailed),
+		Error:     message,
+		Code:      code,
+		Details:   details,
+		Timestamp: time.Now(),
+	}
+
+	return c.Status(status).JSON(response)
+}
+
+func renderProblemError(c *fiber.Ctx, status int, code, message string, details interface{}) error {
+	problem := ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   message,
+		Instance: c.OriginalURL(),
+		Code:     code,
+		Errors:   details,
+	}
+	if base := currentErrorRendering.problemTypeBaseURI; base != "" && code != "" {
+		problem.Type = strings.TrimRight(base, "/") + "/" + strings.ToLower(code)
+	}
+
+	return c.Status(status).JSON(problem, MIMEApplicationProblemJSON)
+}
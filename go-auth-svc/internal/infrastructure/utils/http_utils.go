@@ -1,11 +1,15 @@
 package utils
 
 import (
+	"encoding/base64"
 	"errors"
-	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
+	err_domain "poc-auth-svc/internal/domain/errors"
+	"poc-auth-svc/internal/infrastructure/i18n"
+
 	"github.com/go-playground/validator"
 	"github.com/gofiber/fiber/v2"
 )
@@ -16,15 +20,39 @@ type StandardResponse struct {
 	Message   string      `json:"message"`
 	Data      interface{} `json:"data,omitempty"`
 	Error     string      `json:"error,omitempty"`
+	Code      string      `json:"code,omitempty"`
 	Details   interface{} `json:"details,omitempty"`
 	Timestamp time.Time   `json:"timestamp"`
 }
 
+// LocalsLocale clave de fiber.Ctx.Locals con el i18n.Locale de la petición
+const LocalsLocale = "locale"
+
+var (
+	// ErrBearerTokenRequired la request no trae el header Authorization con un bearer token
+	ErrBearerTokenRequired = err_domain.New(err_domain.AuthenticationRequired)
+	// ErrUnsupportedMediaType el cuerpo de la request no tiene el Content-Type esperado
+	ErrUnsupportedMediaType = err_domain.New(err_domain.UnsupportedMediaType)
+)
+
+// Locale idioma de la respuesta: el que dejó el middleware de localización o, si no corrió, el de Accept-Language
+func Locale(c *fiber.Ctx) i18n.Locale {
+	if locale, ok := c.Locals(LocalsLocale).(i18n.Locale); ok {
+		return locale
+	}
+	return i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+}
+
+// Translate traduce el mensaje al idioma de la petición
+func Translate(c *fiber.Ctx, id i18n.MessageID, args ...interface{}) string {
+	return i18n.Message(Locale(c), id, args...)
+}
+
 // SuccessResponse respuesta estandarizada para casos exitosos
-func SuccessResponse(c *fiber.Ctx, status int, message string, data interface{}) error {
+func SuccessResponse(c *fiber.Ctx, status int, message i18n.MessageID, data interface{}) error {
 	response := StandardResponse{
 		Success:   true,
-		Message:   message,
+		Message:   Translate(c, message),
 		Data:      data,
 		Timestamp: time.Now(),
 	}
@@ -32,81 +60,113 @@ func SuccessResponse(c *fiber.Ctx, status int, message string, data interface{})
 	return c.Status(status).JSON(response)
 }
 
-// ErrorResponse respuesta estandarizada para errores
+// ErrorResponse respuesta de error en el formato negociado: StandardResponse o problem+json (RFC 7807)
 func ErrorResponse(c *fiber.Ctx, status int, message string, details interface{}) error {
-	response := StandardResponse{
-		Success:   false,
-		Message:   "Request failed",
-		Error:     message,
-		Details:   details,
-		Timestamp: time.Now(),
-	}
+	return ErrorResponseWithCode(c, status, "", message, details)
+}
 
-	return c.Status(status).JSON(response)
+// ErrorResponseWithCode igual que ErrorResponse pero con el código de error de dominio para los clientes
+func ErrorResponseWithCode(c *fiber.Ctx, status int, code, message string, details interface{}) error {
+	c.Vary(fiber.HeaderAccept)
+	return errorRenderers[negotiateErrorFormat(c)](c, status, code, message, details)
 }
 
-// ValidateContentType valida que el Content-Type sea el esperado
+// DomainErrorResponse responde con el código y el mensaje traducido de un DomainError con el estado indicado.
+// Cualquier otro error se devuelve tal cual para que el ErrorHandler central lo registre y responda
+// como error interno, sin exponer su detalle al cliente.
+func DomainErrorResponse(c *fiber.Ctx, status int, err error) error {
+	var domainErr *err_domain.DomainError
+	if errors.As(err, &domainErr) {
+		return ErrorResponseWithCode(c, status, string(domainErr.Code), i18n.ErrorMessage(Locale(c), domainErr.Code), nil)
+	}
+	return err
+}
+
+// ValidateContentType valida que el Content-Type sea el esperado; si no, devuelve ErrUnsupportedMediaType
 func ValidateContentType(c *fiber.Ctx, expectedType string) error {
 	contentType := c.Get("Content-Type")
 	if !strings.Contains(contentType, expectedType) {
-		return fmt.Errorf("Content-Type must be %s", expectedType)
+		return ErrUnsupportedMediaType
 	}
 	return nil
 }
 
-// ExtractBearerToken extrae el token del header Authorization
+// ExtractBearerToken extrae el token del header Authorization; sin bearer token devuelve ErrBearerTokenRequired
 func ExtractBearerToken(c *fiber.Ctx) (string, error) {
 	authHeader := c.Get("Authorization")
 	if authHeader == "" {
-		return "", errors.New("authorization header required")
+		return "", ErrBearerTokenRequired
 	}
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 	if tokenString == authHeader {
-		return "", errors.New("bearer token required")
+		return "", ErrBearerTokenRequired
 	}
 
 	return tokenString, nil
 }
 
-// FormatValidationErrors convierte errores de validación en mensajes legibles
-func FormatValidationErrors(err error) []string {
+// ExtractBasicCredentials extrae usuario y contraseña del header Authorization Basic.
+// Los valores se decodifican como application/x-www-form-urlencoded según RFC 6749 sección 2.3.1
+func ExtractBasicCredentials(c *fiber.Ctx) (string, string, bool) {
+	authHeader := c.Get("Authorization")
+	encoded := strings.TrimPrefix(authHeader, "Basic ")
+	if encoded == authHeader {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", false
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", false
+	}
+	if username, err = url.QueryUnescape(username); err != nil {
+		return "", "", false
+	}
+	if password, err = url.QueryUnescape(password); err != nil {
+		return "", "", false
+	}
+	return username, password, true
+}
+
+// FormatValidationErrors convierte errores de validación en mensajes legibles en el idioma indicado
+func FormatValidationErrors(locale i18n.Locale, err error) []string {
 	validationErrors := make([]string, 0)
 
 	if validatorErrors, ok := err.(validator.ValidationErrors); ok {
 		for _, fieldError := range validatorErrors {
-			validationErrors = append(validationErrors, formatValidationError(fieldError))
+			validationErrors = append(validationErrors, formatValidationError(locale, fieldError))
 		}
 	}
 
 	return validationErrors
 }
 
+// validationMessages plantilla de cada regla del validador; las que no figuran usan MsgFieldInvalid
+var validationMessages = map[string]i18n.MessageID{
+	"required": i18n.MsgFieldRequired,
+	"email":    i18n.MsgFieldEmail,
+	"min":      i18n.MsgFieldMin,
+	"max":      i18n.MsgFieldMax,
+	"len":      i18n.MsgFieldLen,
+	"gt":       i18n.MsgFieldGt,
+	"gte":      i18n.MsgFieldGte,
+	"lt":       i18n.MsgFieldLt,
+	"lte":      i18n.MsgFieldLte,
+	"oneof":    i18n.MsgFieldOneOf,
+}
+
 // formatValidationError formatea un error de validación individual
-func formatValidationError(err validator.FieldError) string {
+func formatValidationError(locale i18n.Locale, err validator.FieldError) string {
 	field := strings.ToLower(err.Field())
 
-	switch err.Tag() {
-	case "required":
-		return fmt.Sprintf("%s is required", field)
-	case "email":
-		return fmt.Sprintf("%s must be a valid email address", field)
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters long", field, err.Param())
-	case "max":
-		return fmt.Sprintf("%s must be at most %s characters long", field, err.Param())
-	case "len":
-		return fmt.Sprintf("%s must be exactly %s characters long", field, err.Param())
-	case "gt":
-		return fmt.Sprintf("%s must be greater than %s", field, err.Param())
-	case "gte":
-		return fmt.Sprintf("%s must be greater than or equal to %s", field, err.Param())
-	case "lt":
-		return fmt.Sprintf("%s must be less than %s", field, err.Param())
-	case "lte":
-		return fmt.Sprintf("%s must be less than or equal to %s", field, err.Param())
-	case "oneof":
-		return fmt.Sprintf("%s must be one of: %s", field, err.Param())
-	default:
-		return fmt.Sprintf("%s is invalid", field)
+	id, ok := validationMessages[err.Tag()]
+	if !ok {
+		return i18n.Message(locale, i18n.MsgFieldInvalid, field)
+	}
+	if id == i18n.MsgFieldRequired || id == i18n.MsgFieldEmail {
+		return i18n.Message(locale, id, field)
 	}
+	return i18n.Message(locale, id, field, err.Param())
 }
@@ -0,0 +1,107 @@
+// Package authmw middleware de Fiber para que otros servicios autentiquen los access tokens emitidos
+// por poc-auth-svc, ya sea verificando la firma localmente con el JWKS publicado o consultando el
+// endpoint de introspección, y autoricen las rutas por rol, permiso o scope.
+package authmw
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"poc-auth-svc/internal/domain/valueobjects"
+	"poc-auth-svc/internal/infrastructure/i18n"
+	"poc-auth-svc/internal/infrastructure/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocalsClaims clave de fiber.Ctx.Locals donde New guarda los *Claims del token verificado
+const LocalsClaims = "authmw.claims"
+
+// ErrInvalidToken lo devuelven los Verifier cuando el token no es válido, está vencido o fue revocado
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims claims de un access token de poc-auth-svc
+type Claims = valueobjects.JWTClaims
+
+// Verifier valida un bearer token y devuelve sus claims
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
+
+// New autentica la petición con el bearer token y deja los claims en c.Locals(LocalsClaims)
+func New(verifier Verifier) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, err := utils.ExtractBearerToken(c)
+		if err != nil {
+			c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+			return utils.DomainErrorResponse(c, fiber.StatusUnauthorized, err)
+		}
+		claims, err := verifier.Verify(c.Context(), token)
+		// Un token sin user_id ni client_id no identifica a nadie aunque la firma sea válida
+		if err == nil && claims.UserID == "" && claims.ClientID == "" {
+			err = ErrInvalidToken
+		}
+		if err != nil {
+			c.Set(fiber.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, utils.Translate(c, i18n.MsgInvalidToken), nil)
+		}
+		c.Locals(LocalsClaims, claims)
+		return c.Next()
+	}
+}
+
+// ClaimsFromContext devuelve los claims que dejó New en la petición
+func ClaimsFromContext(c *fiber.Ctx) (*Claims, bool) {
+	claims, ok := c.Locals(LocalsClaims).(*Claims)
+	return claims, ok && claims != nil
+}
+
+// RequireRole exige que el token tenga alguno de los roles indicados; debe ir después de New
+func RequireRole(roles ...string) fiber.Handler {
+	return require(func(claims *Claims) bool {
+		return slices.ContainsFunc(roles, func(role string) bool { return hasRole(claims, role) })
+	})
+}
+
+// RequirePermission exige que el token tenga todos los permisos indicados; debe ir después de New
+func RequirePermission(permissions ...string) fiber.Handler {
+	return require(func(claims *Claims) bool {
+		return containsAll(claims.Permissions, permissions)
+	})
+}
+
+// RequireScope exige que el token se haya emitido con todos los scopes indicados; debe ir después de New
+func RequireScope(scopes ...string) fiber.Handler {
+	return require(func(claims *Claims) bool {
+		return containsAll(strings.Fields(claims.Scope), scopes)
+	})
+}
+
+func require(allowed func(claims *Claims) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := ClaimsFromContext(c)
+		if !ok {
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, utils.Translate(c, i18n.MsgAuthenticationRequired), nil)
+		}
+		if !allowed(claims) {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, utils.Translate(c, i18n.MsgInsufficientPermissions), nil)
+		}
+		return c.Next()
+	}
+}
+
+// hasRole contempla tokens anteriores al claim roles, que solo traen role
+func hasRole(claims *Claims, role string) bool {
+	return slices.Contains(claims.Roles, role) || claims.Role == role
+}
+
+func containsAll(values, required []string) bool {
+	for _, value := range required {
+		if !slices.Contains(values, value) {
+			return false
+		}
+	}
+	return true
+}
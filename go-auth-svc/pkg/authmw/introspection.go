@@ -0,0 +1,92 @@
+package authmw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"poc-auth-svc/internal/application/dtos"
+
+	"github.com/golang-jwt/jwt"
+)
+
+type IntrospectionConfig struct {
+	// URL del endpoint de introspección, por ejemplo https://auth.example.com/oauth2/introspect
+	URL string
+	// ClientID y ClientSecret de un cliente confidencial registrado en el servicio de autenticación
+	ClientID     string
+	ClientSecret string
+	HTTPClient   *http.Client
+}
+
+// IntrospectionVerifier valida cada token contra el endpoint de introspección (RFC 7662). A diferencia
+// de JWKSVerifier detecta tokens revocados y permisos actualizados, a costa de una llamada por petición.
+type IntrospectionVerifier struct {
+	config IntrospectionConfig
+}
+
+func NewIntrospectionVerifier(config IntrospectionConfig) *IntrospectionVerifier {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return &IntrospectionVerifier{config: config}
+}
+
+// Verify implements Verifier.
+func (v *IntrospectionVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.config.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// RFC 6749 sección 2.3.1: las credenciales se codifican como form antes de Basic
+	req.SetBasicAuth(url.QueryEscape(v.config.ClientID), url.QueryEscape(v.config.ClientSecret))
+	resp, err := v.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("introspection request failed with status %d", resp.StatusCode)
+	}
+
+	var introspection dtos.IntrospectionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&introspection); err != nil {
+		return nil, err
+	}
+	// Un refresh token también puede estar activo, pero no sirve como credencial de acceso
+	if !introspection.Active || introspection.TokenType != "Bearer" {
+		return nil, ErrInvalidToken
+	}
+	return newIntrospectionClaims(&introspection), nil
+}
+
+// newIntrospectionClaims arma los claims a partir de la respuesta; username solo viene en tokens de usuario
+func newIntrospectionClaims(introspection *dtos.IntrospectionResponse) *Claims {
+	claims := &Claims{
+		Roles:       introspection.Roles,
+		Permissions: introspection.Permissions,
+		Scope:       introspection.Scope,
+		ClientID:    introspection.ClientID,
+		StandardClaims: jwt.StandardClaims{
+			Id:        introspection.JTI,
+			Subject:   introspection.Subject,
+			Audience:  introspection.Audience,
+			Issuer:    introspection.Issuer,
+			IssuedAt:  introspection.Iat,
+			ExpiresAt: introspection.Exp,
+		},
+	}
+	if introspection.Username != "" {
+		claims.UserID = introspection.Subject
+		claims.Email = introspection.Username
+	}
+	if len(claims.Roles) > 0 {
+		claims.Role = claims.Roles[0]
+	}
+	return claims
+}
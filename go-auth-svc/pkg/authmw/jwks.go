@@ -0,0 +1,158 @@
+package authmw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"poc-auth-svc/internal/domain/valueobjects"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	defaultJWKSRefreshInterval = 5 * time.Minute
+	// minJWKSRefreshInterval limita las descargas provocadas por tokens con un kid desconocido
+	minJWKSRefreshInterval = 30 * time.Second
+	defaultHTTPTimeout     = 5 * time.Second
+	// accessTokenType valor del header typ de los access tokens (RFC 9068); descarta ID tokens,
+	// desafíos MFA y otros tokens internos firmados con las mismas claves
+	accessTokenType = "at+jwt"
+)
+
+type JWKSConfig struct {
+	// URL del JWKS, por ejemplo https://auth.example.com/.well-known/jwks.json
+	URL string
+	// Issuer si no está vacío se exige que coincida con el claim iss
+	Issuer string
+	// Audience si no está vacío se exige que coincida con el claim aud
+	Audience string
+	// RefreshInterval cada cuánto se vuelven a descargar las claves; por defecto 5 minutos
+	RefreshInterval time.Duration
+	HTTPClient      *http.Client
+}
+
+type jwksKey struct {
+	algorithm string
+	publicKey interface{}
+}
+
+// JWKSVerifier verifica la firma de los access tokens con las claves públicas del JWKS, sin llamar
+// al servicio de autenticación en cada petición. No detecta tokens revocados antes de su expiración;
+// para eso usar IntrospectionVerifier. Los tokens firmados con HS256 no pueden verificarse así porque
+// el secreto no se publica.
+type JWKSVerifier struct {
+	config    JWKSConfig
+	mu        sync.RWMutex
+	keys      map[string]jwksKey
+	fetchedAt time.Time
+}
+
+func NewJWKSVerifier(config JWKSConfig) *JWKSVerifier {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = defaultJWKSRefreshInterval
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return &JWKSVerifier{config: config}
+}
+
+// Verify implements Verifier.
+func (v *JWKSVerifier) Verify(ctx context.Context, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != accessTokenType {
+			return nil, fmt.Errorf("unexpected token type %q", typ)
+		}
+		kid, _ := token.Header["kid"].(string)
+		key, err := v.key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		// Se exige el algoritmo de la clave para evitar ataques de confusión de algoritmo
+		if token.Method.Alg() != key.algorithm {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+		return key.publicKey, nil
+	})
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if v.config.Issuer != "" && claims.Issuer != v.config.Issuer {
+		return nil, ErrInvalidToken
+	}
+	if v.config.Audience != "" && !claims.VerifyAudience(v.config.Audience, true) {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// key busca la clave por kid y descarga el JWKS si las claves están vencidas o el kid es nuevo (rotación)
+func (v *JWKSVerifier) key(ctx context.Context, kid string) (jwksKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	stale := time.Since(v.fetchedAt) > v.config.RefreshInterval
+	canRefresh := time.Since(v.fetchedAt) > minJWKSRefreshInterval
+	v.mu.RUnlock()
+	if ok && !stale {
+		return key, nil
+	}
+	if !ok && !canRefresh {
+		return jwksKey{}, fmt.Errorf("unknown key id %s", kid)
+	}
+	if err := v.refresh(ctx); err != nil {
+		// Con el JWKS caído se siguen aceptando las claves ya conocidas
+		if ok {
+			return key, nil
+		}
+		return jwksKey{}, err
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if key, ok = v.keys[kid]; !ok {
+		return jwksKey{}, fmt.Errorf("unknown key id %s", kid)
+	}
+	return key, nil
+}
+
+func (v *JWKSVerifier) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.config.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwks request failed with status %d", resp.StatusCode)
+	}
+	var document struct {
+		Keys []valueobjects.JWK `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return err
+	}
+
+	keys := make(map[string]jwksKey, len(document.Keys))
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		publicKey, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = jwksKey{algorithm: jwk.Algorithm, publicKey: publicKey}
+	}
+	v.mu.Lock()
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	v.mu.Unlock()
+	return nil
+}